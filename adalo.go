@@ -3,6 +3,7 @@ package adalo
 
 import "errors"
 
+// The errors below are returned by the API wrapped in an *APIError and should be compared with errors.Is.
 var (
 	// ErrorUnauthorized is returned by the API when the ApiKey was invalid.
 	ErrorUnauthorized = errors.New("unauthorized")
@@ -1,7 +1,9 @@
 package adalo
 
 import (
+	"github.com/be-foo/adalo-sdk-go/adalotest"
 	"os"
+	"testing"
 )
 
 // testConfig defines a config argument for the setup function
@@ -22,24 +24,41 @@ const (
 	validApp
 )
 
-// validApiKey is a copy of the valid api key used in the tests.
-// We created this because we want to make tests where ApiKey is invalid and be able to reset it back to the valid api key.
-var validApiKey string
+// validApiKey is the api key accepted by the testServer.
+const validApiKey = "test-api-key"
 
-// validAppID is a copy of the valid app id used in the tests.
-// We created this because we want to make tests where AppKey is invalid and be able to reset it back to the valid app id.
-var validAppID string
+// validAppID is the id of the app served by the testServer.
+const validAppID = "test-app-id"
 
-func init() {
-	if os.Getenv("TEST_API_KEY") == "" {
-		panic("environment variable TEST_API_KEY is not set")
-	}
-	validApiKey = os.Getenv("TEST_API_KEY")
+// testCollectionID is the id of the collection the tests of the package-level API run against.
+const testCollectionID = "t_persons"
 
-	if os.Getenv("TEST_APP_ID") == "" {
-		panic("environment variable TEST_APP_ID is not set")
-	}
-	validAppID = os.Getenv("TEST_APP_ID")
+// testServer is the fake Adalo API the default client sends its requests to during the tests.
+var testServer *adalotest.Server
+
+func TestMain(m *testing.M) {
+	testServer = adalotest.NewServer(validApiKey, validAppID)
+	testServer.AddCollection(testCollectionID)
+	testServer.AddUser("john.doe@gmail.com")
+
+	defaultClient.collectionsBaseURL = testServer.URL
+	defaultClient.notificationsBaseURL = testServer.URL
+	defaultClient.rateLimiter = nil
+
+	code := m.Run()
+	testServer.Close()
+	os.Exit(code)
+}
+
+// newTestClient returns a client sending its requests to the fake Adalo API without rate limit.
+// It is the in-package counterpart of testclient.New, which cannot be imported by the tests of this package.
+func newTestClient(srv *adalotest.Server, opts ...Option) *Client {
+	opts = append([]Option{
+		WithCollectionsBaseURL(srv.URL),
+		WithNotificationsBaseURL(srv.URL),
+		WithRateLimiter(nil),
+	}, opts...)
+	return NewClient(srv.APIKey, srv.AppID, opts...)
 }
 
 // setup is meant to be called at the beginning of each test to setup some conditions.
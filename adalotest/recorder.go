@@ -0,0 +1,247 @@
+package adalotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrorUnmatchedRequest is returned by a replaying Recorder for requests that are not in the cassette.
+var ErrorUnmatchedRequest = errors.New("request not found in cassette")
+
+// Mode determines whether a Recorder records or replays interactions.
+type Mode int
+
+// list of modes of a Recorder
+const (
+	// ModeAuto replays the cassette if it exists and records it otherwise
+	ModeAuto Mode = iota
+
+	// ModeReplay replays the cassette, which must exist
+	ModeReplay
+
+	// ModeRecord sends all requests and overwrites the cassette
+	ModeRecord
+)
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(r *Recorder)
+
+// WithRecordTransport sets the http.RoundTripper requests are sent with while recording,
+// which defaults to http.DefaultTransport.
+func WithRecordTransport(transport http.RoundTripper) RecorderOption {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithReplacement replaces value with placeholder in the recorded URLs and bodies, e.g. to keep the
+// app ID or the API key out of the cassette. Clients replaying the cassette must use the placeholder
+// instead of the value. Empty values are ignored, so the real value can be read from an environment
+// variable that is only set while recording.
+func WithReplacement(value, placeholder string) RecorderOption {
+	return func(r *Recorder) {
+		if value != "" {
+			r.replacements = append(r.replacements, value, placeholder)
+		}
+	}
+}
+
+// Recorder is a http.RoundTripper that records the interactions with the Adalo API to a cassette file
+// and replays them in later runs. The Authorization header is never recorded.
+//
+//	rec, err := adalotest.NewRecorder("testdata/persons.json", adalotest.ModeAuto,
+//		adalotest.WithReplacement(os.Getenv("ADALO_API_KEY"), "API_KEY"))
+//	defer rec.Close()
+//
+//	client := adalo.NewClient(apiKey, appID, adalo.WithTransport(rec))
+//
+// While replaying, every recorded interaction is used once, in the recorded order of interactions
+// with the same method, URL and body. Requests without a matching interaction fail with ErrorUnmatchedRequest.
+type Recorder struct {
+	path         string
+	recording    bool
+	transport    http.RoundTripper
+	replacements []string
+
+	mu           sync.Mutex
+	interactions []*interaction
+}
+
+// cassette is the content of a cassette file.
+type cassette struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+// interaction is a recorded request and its response.
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+
+	// used marks interactions that were already replayed
+	used bool
+}
+
+// recordedRequest is the recorded part of a request.
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// recordedResponse is the recorded part of a response.
+type recordedResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. In ModeReplay and in ModeAuto
+// with an existing cassette, the cassette is read immediately.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{path: path, transport: http.DefaultTransport}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	data, err := os.ReadFile(path)
+	switch {
+	case mode == ModeRecord:
+		r.recording = true
+		return r, nil
+	case mode == ModeAuto && errors.Is(err, os.ErrNotExist):
+		r.recording = true
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", path, err)
+	}
+	r.interactions = c.Interactions
+	return r, nil
+}
+
+// Recording reports whether the Recorder records interactions rather than replaying them.
+func (r *Recorder) Recording() bool {
+	return r.recording
+}
+
+// RoundTrip records or replays the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+	recorded := recordedRequest{
+		Method: req.Method,
+		URL:    r.replace(req.URL.String()),
+		Body:   r.replace(string(body)),
+	}
+
+	if r.recording {
+		return r.record(req, body, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+// record sends the request and adds the interaction to the cassette.
+func (r *Recorder) record(req *http.Request, body []byte, recorded recordedRequest) (*http.Response, error) {
+	sent := req.Clone(req.Context())
+	sent.Body = io.NopCloser(bytes.NewReader(body))
+	res, err := r.transport.RoundTrip(sent)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	header := res.Header.Clone()
+	for _, values := range header {
+		for i, value := range values {
+			values[i] = r.replace(value)
+		}
+	}
+
+	r.mu.Lock()
+	r.interactions = append(r.interactions, &interaction{
+		Request: recorded,
+		Response: recordedResponse{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       r.replace(string(resBody)),
+		},
+	})
+	r.mu.Unlock()
+	return res, nil
+}
+
+// replay returns the response of the first unused interaction matching the request.
+func (r *Recorder) replay(req *http.Request, recorded recordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, i := range r.interactions {
+		if i.used || i.Request != recorded {
+			continue
+		}
+		i.used = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+			StatusCode:    i.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        i.Response.Header.Clone(),
+			Body:          io.NopCloser(strings.NewReader(i.Response.Body)),
+			ContentLength: int64(len(i.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrorUnmatchedRequest, recorded.Method, recorded.URL)
+}
+
+// Close writes the recorded interactions to the cassette file. It does nothing when replaying.
+func (r *Recorder) Close() error {
+	if !r.recording {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(cassette{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// replace replaces the values configured with WithReplacement by their placeholders.
+func (r *Recorder) replace(s string) string {
+	if len(r.replacements) == 0 {
+		return s
+	}
+	return strings.NewReplacer(r.replacements...).Replace(s)
+}
@@ -0,0 +1,90 @@
+package adalotest
+
+import (
+	"context"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "persons.json")
+	ctx := context.Background()
+
+	// record against a server with real credentials
+	srv := NewServer("secret-key", "secret-app")
+	srv.Seed("persons", map[string]interface{}{"Name": "John", "Age": 21})
+
+	rec, err := NewRecorder(path, ModeAuto, WithReplacement("secret-key", "API_KEY"), WithReplacement("secret-app", "APP_ID"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.True(t, rec.Recording())
+
+	client := adalo.NewClient("secret-key", "secret-app", adalo.WithCollectionsBaseURL(srv.URL), adalo.WithTransport(rec))
+	persons := client.Collection("persons")
+
+	var recorded record
+	assert.Nil(t, persons.GetContext(ctx, 1, &recorded))
+	assert.Nil(t, persons.UpdateContext(ctx, 1, map[string]interface{}{"Age": 22}, nil))
+	assert.Nil(t, persons.GetContext(ctx, 1, &recorded))
+	assert.True(t, errors.Is(persons.GetContext(ctx, 2, nil), adalo.ErrorResourceNotFound))
+	assert.Nil(t, rec.Close())
+	srv.Close()
+
+	cassette, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(cassette), "secret")
+	assert.NotContains(t, string(cassette), "Authorization")
+	assert.Contains(t, string(cassette), "/apps/APP_ID/collections/persons/1")
+
+	// replay without a server using the placeholders
+	rec, err = NewRecorder(path, ModeAuto)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, rec.Recording())
+
+	client = adalo.NewClient("API_KEY", "APP_ID", adalo.WithCollectionsBaseURL(srv.URL), adalo.WithTransport(rec), adalo.WithRetryPolicy(adalo.NoRetries))
+	persons = client.Collection("persons")
+
+	var replayed record
+	assert.Nil(t, persons.GetContext(ctx, 1, &replayed))
+	assert.Equal(t, 21, replayed.Age)
+	assert.Nil(t, persons.UpdateContext(ctx, 1, map[string]interface{}{"Age": 22}, nil))
+	assert.Nil(t, persons.GetContext(ctx, 1, &replayed))
+	assert.Equal(t, recorded, replayed)
+	assert.True(t, errors.Is(persons.GetContext(ctx, 2, nil), adalo.ErrorResourceNotFound))
+
+	// every interaction is replayed once
+	err = persons.GetContext(ctx, 1, &replayed)
+	assert.True(t, errors.Is(err, ErrorUnmatchedRequest))
+
+	err = persons.UpdateContext(ctx, 1, map[string]interface{}{"Age": 23}, nil)
+	assert.True(t, errors.Is(err, ErrorUnmatchedRequest))
+	assert.Nil(t, rec.Close())
+}
+
+func TestNewRecorder(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewRecorder(filepath.Join(dir, "missing.json"), ModeReplay)
+	assert.True(t, errors.Is(err, os.ErrNotExist))
+
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.Nil(t, os.WriteFile(invalid, []byte("not json"), 0644))
+	_, err = NewRecorder(invalid, ModeAuto)
+	assert.Error(t, err)
+
+	rec, err := NewRecorder(invalid, ModeRecord)
+	assert.Nil(t, err)
+	assert.True(t, rec.Recording())
+	assert.Nil(t, rec.Close())
+
+	rec, err = NewRecorder(invalid, ModeReplay)
+	assert.Nil(t, err)
+	assert.False(t, rec.Recording())
+}
@@ -0,0 +1,597 @@
+// Package adalotest provides an in-memory fake of the Adalo API for hermetic tests.
+//
+// A Server emulates the collections and notifications endpoints, including authentication,
+// pagination, filtering and the error responses of the Adalo API. Point a client at it with:
+//
+//	srv := adalotest.NewServer("api-key", "app-id")
+//	defer srv.Close()
+//
+//	srv.Seed("persons", map[string]interface{}{"Name": "John"})
+//
+//	client := adalo.NewClient(srv.APIKey, srv.AppID,
+//		adalo.WithCollectionsBaseURL(srv.URL),
+//		adalo.WithNotificationsBaseURL(srv.URL),
+//	)
+//
+// Package testclient creates such a client with testclient.New(srv).
+package adalotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxPageSize is the maximum number of records returned per page, which is also the default page size.
+const MaxPageSize = 100
+
+// TimeLayout is the format of the created_at and updated_at fields set by the Server.
+const TimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// error messages returned by the Server, which match the messages of the Adalo API
+const (
+	messageUnauthorized     = "Unauthorized"
+	messageAppMismatch      = "Access token / app mismatch"
+	messageResourceNotFound = "Resource not found"
+	messageUserNotFound     = "User not found"
+)
+
+// Notification is a push notification sent through the Server.
+type Notification struct {
+	// AppID the notification was sent for
+	AppID string
+
+	// Email of the recipient
+	Email string
+
+	// Title and Body of the notification
+	Title string
+	Body  string
+}
+
+// Request is a request received by the Server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+}
+
+// Server is an in-memory fake of the Adalo API. It is safe for concurrent use.
+type Server struct {
+	// URL of the server, to be used as base URL for collections and notifications
+	URL string
+
+	// APIKey is the only API key accepted by the server
+	APIKey string
+
+	// AppID is the ID of the only app served by the server
+	AppID string
+
+	srv           *httptest.Server
+	mu            sync.Mutex
+	collections   map[string]*collection
+	users         map[string]bool
+	notifications []Notification
+	requests      []Request
+	lastTime      time.Time
+	noContent     bool
+}
+
+// collection holds the records of a collection by their id.
+type collection struct {
+	records map[int]map[string]interface{}
+	nextID  int
+}
+
+// NewServer starts a Server that accepts the apiKey for the app with the ID appID.
+// The server should be closed with Close.
+func NewServer(apiKey, appID string) *Server {
+	s := &Server{
+		APIKey:      apiKey,
+		AppID:       appID,
+		collections: map[string]*collection{},
+		users:       map[string]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /apps/{app}/collections/{collection}", s.list)
+	mux.HandleFunc("POST /apps/{app}/collections/{collection}", s.insert)
+	mux.HandleFunc("GET /apps/{app}/collections/{collection}/{id}", s.get)
+	mux.HandleFunc("PUT /apps/{app}/collections/{collection}/{id}", s.update)
+	mux.HandleFunc("DELETE /apps/{app}/collections/{collection}/{id}", s.delete)
+	mux.HandleFunc("POST /notifications", s.notify)
+
+	s.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query()})
+		s.mu.Unlock()
+
+		if r.Header.Get("Authorization") != "Bearer "+s.APIKey {
+			writeError(w, http.StatusUnauthorized, messageUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// AddCollection creates an empty collection. Requests to collections that were neither added nor seeded fail with 404.
+func (s *Server) AddCollection(collectionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collection(collectionID, true)
+}
+
+// Seed adds the records to the collection, creating the collection if necessary, and returns their IDs.
+// Each record must marshal to a JSON object. Records without id get the next free ID, and created_at
+// and updated_at are set unless present. Seed panics if a record cannot be marshaled.
+func (s *Server) Seed(collectionID string, records ...interface{}) []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(collectionID, true)
+	ids := make([]int, len(records))
+	for i, record := range records {
+		data, err := json.Marshal(record)
+		if err != nil {
+			panic(fmt.Sprintf("adalotest: seeding %s: %s", collectionID, err))
+		}
+		fields, err := decodeObject(data)
+		if err != nil {
+			panic(fmt.Sprintf("adalotest: seeding %s: %s", collectionID, err))
+		}
+
+		id, _ := toInt(fields["id"])
+		if id <= 0 {
+			id = c.nextID
+		}
+		if id >= c.nextID {
+			c.nextID = id + 1
+		}
+		fields["id"] = id
+
+		now := s.now()
+		if fields["created_at"] == nil {
+			fields["created_at"] = now
+		}
+		if fields["updated_at"] == nil {
+			fields["updated_at"] = now
+		}
+		c.records[id] = fields
+		ids[i] = id
+	}
+	return ids
+}
+
+// WithRecords seeds the records into the collection like Seed and returns the server,
+// so a seeded server can be started in a single expression:
+//
+//	srv := adalotest.NewServer("key", "app").WithRecords("persons", person1, person2)
+func (s *Server) WithRecords(collectionID string, records ...interface{}) *Server {
+	s.Seed(collectionID, records...)
+	return s
+}
+
+// Records returns copies of the records of the collection ordered by ID.
+// Numbers in the records are json.Number values, except for the int id.
+func (s *Server) Records(collectionID string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(collectionID, false)
+	if c == nil {
+		return nil
+	}
+	records := make([]map[string]interface{}, 0, len(c.records))
+	for _, id := range c.ids() {
+		records = append(records, copyRecord(c.records[id]))
+	}
+	return records
+}
+
+// Record returns a copy of the record with the given id and reports whether it exists.
+func (s *Server) Record(collectionID string, id int) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(collectionID, false)
+	if c == nil || c.records[id] == nil {
+		return nil, false
+	}
+	return copyRecord(c.records[id]), true
+}
+
+// RespondNoContentToDeletes makes the server respond to successful deletes with 204 No Content
+// instead of 200 and the deleted record. The Adalo API responds with 204 to some successful deletes,
+// which the client cannot tell apart from deletes of records that do not exist.
+func (s *Server) RespondNoContentToDeletes() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.noContent = true
+}
+
+// AddUser adds a user of the app, who can receive push notifications.
+func (s *Server) AddUser(email string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[email] = true
+}
+
+// Notifications returns the push notifications sent through the server.
+func (s *Server) Notifications() []Notification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Notification(nil), s.notifications...)
+}
+
+// Requests returns the requests received by the server, including rejected ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// CountRequests returns the number of received requests with the method whose path starts with pathPrefix.
+// An empty method counts requests of any method.
+func (s *Server) CountRequests(method, pathPrefix string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, r := range s.requests {
+		if (method == "" || r.Method == method) && strings.HasPrefix(r.Path, pathPrefix) {
+			count++
+		}
+	}
+	return count
+}
+
+// ResetRequests clears the recorded requests.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// collection returns the collection with the given id. It creates missing collections if create is set.
+func (s *Server) collection(id string, create bool) *collection {
+	c := s.collections[id]
+	if c == nil && create {
+		c = &collection{records: map[int]map[string]interface{}{}, nextID: 1}
+		s.collections[id] = c
+	}
+	return c
+}
+
+// now returns the formatted current time, which is strictly increasing,
+// so every update moves updated_at even within the same millisecond.
+func (s *Server) now() string {
+	t := time.Now().UTC().Truncate(time.Millisecond)
+	if !t.After(s.lastTime) {
+		t = s.lastTime.Add(time.Millisecond)
+	}
+	s.lastTime = t
+	return t.Format(TimeLayout)
+}
+
+// ids returns the IDs of the records in ascending order.
+func (c *collection) ids() []int {
+	ids := make([]int, 0, len(c.records))
+	for id := range c.records {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// resolve checks the app of the request and returns the requested collection.
+// It writes an error response and returns nil if the app or collection does not exist.
+// The caller must hold s.mu.
+func (s *Server) resolve(w http.ResponseWriter, r *http.Request) *collection {
+	if r.PathValue("app") != s.AppID {
+		writeError(w, http.StatusForbidden, messageAppMismatch)
+		return nil
+	}
+	c := s.collection(r.PathValue("collection"), false)
+	if c == nil {
+		writeError(w, http.StatusNotFound, messageResourceNotFound)
+	}
+	return c
+}
+
+// resolveRecord returns the requested collection and record id. The record is nil if it does not exist.
+// The caller must hold s.mu.
+func (s *Server) resolveRecord(w http.ResponseWriter, r *http.Request) (*collection, int, bool) {
+	c := s.resolve(w, r)
+	if c == nil {
+		return nil, 0, false
+	}
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, messageResourceNotFound)
+		return nil, 0, false
+	}
+	return c, id, true
+}
+
+// list handles requests for a page of records.
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.resolve(w, r)
+	if c == nil {
+		return
+	}
+
+	query := r.URL.Query()
+	offset, limit := 0, MaxPageSize
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid offset")
+			return
+		}
+		offset = n
+	}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		if n > 0 && n < MaxPageSize {
+			limit = n
+		}
+	}
+
+	filterKey, filterValue := query.Get("filterKey"), query.Get("filterValue")
+	records := []map[string]interface{}{}
+	for _, id := range c.ids() {
+		if filterKey != "" && !matches(c.records[id][filterKey], filterValue) {
+			continue
+		}
+		records = append(records, c.records[id])
+	}
+
+	if offset > len(records) {
+		offset = len(records)
+	}
+	end := offset + limit
+	if end > len(records) {
+		end = len(records)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"records": records[offset:end], "offset": end})
+}
+
+// get handles requests for a single record.
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, id, ok := s.resolveRecord(w, r)
+	if !ok {
+		return
+	}
+	if c.records[id] == nil {
+		writeError(w, http.StatusNotFound, messageResourceNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, c.records[id])
+}
+
+// insert handles requests creating a record.
+func (s *Server) insert(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.resolve(w, r)
+	if c == nil {
+		return
+	}
+	fields, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	record := map[string]interface{}{}
+	for key, value := range fields {
+		record[key] = value
+	}
+	now := s.now()
+	record["id"] = c.nextID
+	record["created_at"] = now
+	record["updated_at"] = now
+	c.records[c.nextID] = record
+	c.nextID++
+
+	writeJSON(w, http.StatusOK, record)
+}
+
+// update handles requests updating the fields of a record.
+func (s *Server) update(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, id, ok := s.resolveRecord(w, r)
+	if !ok {
+		return
+	}
+	record := c.records[id]
+	if record == nil {
+		writeError(w, http.StatusNotFound, messageResourceNotFound)
+		return
+	}
+	fields, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	for key, value := range fields {
+		record[key] = value
+	}
+	record["updated_at"] = s.now()
+	writeJSON(w, http.StatusOK, record)
+}
+
+// delete handles requests deleting a record. Like Adalo, it responds with 204 No Content if the record does not exist,
+// and also if it was deleted with RespondNoContentToDeletes.
+func (s *Server) delete(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, id, ok := s.resolveRecord(w, r)
+	if !ok {
+		return
+	}
+	record := c.records[id]
+	if record == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	delete(c.records, id)
+	if s.noContent {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+// notify handles requests sending a push notification.
+func (s *Server) notify(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var input struct {
+		AppID    string `json:"appId"`
+		Audience struct {
+			Email string `json:"email"`
+		} `json:"audience"`
+		Notification struct {
+			Title string `json:"titleText"`
+			Body  string `json:"bodyText"`
+		} `json:"notification"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return
+	}
+	if input.AppID != s.AppID {
+		writeError(w, http.StatusForbidden, messageAppMismatch)
+		return
+	}
+	if !s.users[input.Audience.Email] {
+		writeError(w, http.StatusBadRequest, messageUserNotFound)
+		return
+	}
+
+	s.notifications = append(s.notifications, Notification{
+		AppID: input.AppID,
+		Email: input.Audience.Email,
+		Title: input.Notification.Title,
+		Body:  input.Notification.Body,
+	})
+	writeJSON(w, http.StatusOK, map[string]int{"successful": 1})
+}
+
+// readObject decodes the JSON object in the request body without the fields set by the server.
+// It writes an error response and returns false if the body is not a JSON object.
+func readObject(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return nil, false
+	}
+	fields, err := decodeObject(buf.Bytes())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid JSON")
+		return nil, false
+	}
+	delete(fields, "id")
+	delete(fields, "created_at")
+	delete(fields, "updated_at")
+	return fields, true
+}
+
+// decodeObject decodes a JSON object, keeping numbers as json.Number.
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("record is not a JSON object: %s", data)
+	}
+	return fields, nil
+}
+
+// matches reports whether the value of a field matches the filter value.
+// Relationship fields match if any of the related IDs matches.
+func matches(value interface{}, filter string) bool {
+	switch v := value.(type) {
+	case nil:
+		return filter == ""
+	case string:
+		return v == filter
+	case json.Number:
+		return v.String() == filter
+	case []interface{}:
+		for _, item := range v {
+			if matches(item, filter) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == filter
+	}
+}
+
+// toInt converts a decoded JSON number to an int.
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case json.Number:
+		i, err := v.Int64()
+		return int(i), err == nil
+	}
+	return 0, false
+}
+
+// copyRecord returns a shallow copy of the record.
+func copyRecord(record map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(record))
+	for key, value := range record {
+		result[key] = value
+	}
+	return result
+}
+
+// writeJSON writes v as JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of the Adalo API.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,220 @@
+package adalotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// record is a record of the persons collection used in the tests
+type record struct {
+	adalo.Record
+	Name  string `json:"Name"`
+	Age   int    `json:"Age"`
+	Tasks []int  `json:"Tasks"`
+}
+
+// newClient returns a client sending its requests to the server.
+func newClient(srv *Server, apiKey, appID string) *adalo.Client {
+	return adalo.NewClient(apiKey, appID,
+		adalo.WithCollectionsBaseURL(srv.URL),
+		adalo.WithNotificationsBaseURL(srv.URL),
+		adalo.WithRateLimiter(nil),
+		adalo.WithRetryPolicy(adalo.NoRetries),
+	)
+}
+
+func TestServer_collections(t *testing.T) {
+	srv := NewServer("key", "app")
+	defer srv.Close()
+
+	ids := srv.Seed("persons",
+		map[string]interface{}{"Name": "John", "Age": 21, "Tasks": []int{1, 2}},
+		map[string]interface{}{"id": 10, "Name": "Jane", "Age": 28, "Tasks": []int{2}},
+	)
+	assert.Equal(t, []int{1, 10}, ids)
+
+	persons := newClient(srv, "key", "app").Collection("persons")
+	ctx := context.Background()
+
+	t.Run("get", func(t *testing.T) {
+		var result record
+		assert.Nil(t, persons.GetContext(ctx, 10, &result))
+		assert.Equal(t, "Jane", result.Name)
+		assert.False(t, result.CreatedAt.IsZero())
+
+		err := persons.GetContext(ctx, 99, &result)
+		assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		var result record
+		assert.Nil(t, persons.InsertContext(ctx, map[string]interface{}{"id": 5, "Name": "Richard", "Age": 89}, &result))
+		assert.Equal(t, 11, result.ID)
+		assert.Equal(t, result.CreatedAt, result.UpdatedAt)
+
+		stored, ok := srv.Record("persons", 11)
+		assert.True(t, ok)
+		assert.Equal(t, "Richard", stored["Name"])
+		assert.Equal(t, json.Number("89"), stored["Age"])
+	})
+
+	t.Run("update", func(t *testing.T) {
+		var before, after record
+		assert.Nil(t, persons.GetContext(ctx, 1, &before))
+		assert.Nil(t, persons.UpdateContext(ctx, 1, map[string]interface{}{"Age": 22}, &after))
+		assert.Equal(t, "John", after.Name)
+		assert.Equal(t, 22, after.Age)
+		assert.Equal(t, before.CreatedAt, after.CreatedAt)
+		assert.True(t, after.UpdatedAt.After(before.UpdatedAt))
+
+		err := persons.UpdateContext(ctx, 99, map[string]interface{}{"Age": 22}, nil)
+		assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		page, err := persons.List(ctx, adalo.ListOptions{Limit: 2})
+		assert.Nil(t, err)
+		assert.Len(t, page.Records, 2)
+		assert.True(t, page.HasMore())
+
+		page, err = persons.List(ctx, page.Next())
+		assert.Nil(t, err)
+		assert.Len(t, page.Records, 1)
+
+		var all []record
+		assert.Nil(t, persons.AllContext(ctx, &all))
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		var result []record
+		assert.Nil(t, persons.Where("Name", "Jane").AllContext(ctx, &result))
+		assert.Len(t, result, 1)
+
+		assert.Nil(t, persons.Where("Age", "22").AllContext(ctx, &result))
+		assert.Len(t, result, 1)
+
+		assert.Nil(t, persons.Where("Tasks", "2").AllContext(ctx, &result))
+		assert.Len(t, result, 2)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert.Nil(t, persons.DeleteContext(ctx, 11))
+		_, ok := srv.Record("persons", 11)
+		assert.False(t, ok)
+
+		err := persons.DeleteContext(ctx, 11)
+		assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+	})
+
+	t.Run("delete with no content", func(t *testing.T) {
+		srv := NewServer("key", "app")
+		defer srv.Close()
+		srv.RespondNoContentToDeletes()
+		ids := srv.Seed("persons", map[string]interface{}{"Name": "John"})
+
+		// the record is deleted, but the client reports the 204 of Adalo as not found
+		err := newClient(srv, "key", "app").Collection("persons").DeleteContext(ctx, ids[0])
+		assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+		_, ok := srv.Record("persons", ids[0])
+		assert.False(t, ok)
+	})
+
+	t.Run("unknown collection", func(t *testing.T) {
+		err := newClient(srv, "key", "app").Collection("tasks").AllContext(ctx, &[]record{})
+		assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+	})
+}
+
+func TestServer_auth(t *testing.T) {
+	srv := NewServer("key", "app")
+	defer srv.Close()
+	srv.AddCollection("persons")
+	ctx := context.Background()
+
+	err := newClient(srv, "invalid", "app").Collection("persons").GetContext(ctx, 1, nil)
+	assert.True(t, errors.Is(err, adalo.ErrorUnauthorized))
+
+	err = newClient(srv, "key", "other").Collection("persons").GetContext(ctx, 1, nil)
+	assert.True(t, errors.Is(err, adalo.ErrorAppMismatch))
+
+	_, err = newClient(srv, "key", "other").SendPushNotificationContext(ctx, &adalo.PushNotificationInput{
+		Audience: adalo.PushNotificationAudienceInput{Email: "john.doe@gmail.com"},
+	})
+	assert.True(t, errors.Is(err, adalo.ErrorAppMismatch))
+
+	assert.Len(t, srv.Requests(), 3)
+	assert.Equal(t, 2, srv.CountRequests(http.MethodGet, "/apps/"))
+	assert.Equal(t, 1, srv.CountRequests(http.MethodPost, "/notifications"))
+	assert.Equal(t, 3, srv.CountRequests("", ""))
+	assert.Equal(t, 0, srv.CountRequests(http.MethodGet, "/apps/app/collections/tasks"))
+	srv.ResetRequests()
+	assert.Empty(t, srv.Requests())
+}
+
+func TestServer_notifications(t *testing.T) {
+	srv := NewServer("key", "app")
+	defer srv.Close()
+	srv.AddUser("john.doe@gmail.com")
+
+	client := newClient(srv, "key", "app")
+	successful, err := client.SendPushNotificationContext(context.Background(), &adalo.PushNotificationInput{
+		Audience:     adalo.PushNotificationAudienceInput{Email: "john.doe@gmail.com"},
+		Notification: adalo.PushNotificationContentInput{Title: "Hello", Body: "World"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, successful)
+	assert.Equal(t, []Notification{{AppID: "app", Email: "john.doe@gmail.com", Title: "Hello", Body: "World"}}, srv.Notifications())
+
+	_, err = client.SendPushNotificationContext(context.Background(), &adalo.PushNotificationInput{
+		Audience: adalo.PushNotificationAudienceInput{Email: "jane.doe@gmail.com"},
+	})
+	assert.True(t, errors.Is(err, adalo.ErrorUserNotFound))
+	assert.Len(t, srv.Notifications(), 1)
+}
+
+func TestServer_now(t *testing.T) {
+	srv := NewServer("key", "app")
+	defer srv.Close()
+
+	first, err := time.Parse(TimeLayout, srv.now())
+	assert.Nil(t, err)
+	second, err := time.Parse(TimeLayout, srv.now())
+	assert.Nil(t, err)
+	assert.True(t, second.After(first))
+}
+
+func TestServer_Seed(t *testing.T) {
+	srv := NewServer("key", "app")
+	defer srv.Close()
+
+	assert.Panics(t, func() { srv.Seed("persons", "not an object") })
+	assert.Nil(t, srv.Records("tasks"))
+
+	srv.Seed("persons", map[string]interface{}{"Name": "John", "created_at": "2021-03-04T10:15:30.000Z"})
+	records := srv.Records("persons")
+	assert.Len(t, records, 1)
+	assert.Equal(t, "2021-03-04T10:15:30.000Z", records[0]["created_at"])
+
+	// records are copies
+	records[0]["Name"] = "Jane"
+	stored, _ := srv.Record("persons", 1)
+	assert.Equal(t, "John", stored["Name"])
+}
+
+func TestServer_WithRecords(t *testing.T) {
+	srv := NewServer("key", "app").WithRecords("persons", map[string]interface{}{"Name": "John"}, map[string]interface{}{"Name": "Jane"})
+	defer srv.Close()
+
+	records := srv.Records("persons")
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, 2, records[1]["id"])
+		assert.Equal(t, "Jane", records[1]["Name"])
+	}
+}
@@ -0,0 +1,26 @@
+// Package testclient creates clients sending their requests to an adalotest.Server:
+//
+//	srv := adalotest.NewServer("api-key", "app-id")
+//	defer srv.Close()
+//
+//	persons := testclient.New(srv).Collection("persons")
+//
+// It is separate from adalotest, because the tests of package adalo use adalotest,
+// which therefore must not import adalo.
+package testclient
+
+import (
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+)
+
+// New returns a client with the credentials of the server, which sends the requests for
+// collections and notifications to the server without rate limit. The passed options are applied last.
+func New(srv *adalotest.Server, opts ...adalo.Option) *adalo.Client {
+	opts = append([]adalo.Option{
+		adalo.WithCollectionsBaseURL(srv.URL),
+		adalo.WithNotificationsBaseURL(srv.URL),
+		adalo.WithRateLimiter(nil),
+	}, opts...)
+	return adalo.NewClient(srv.APIKey, srv.AppID, opts...)
+}
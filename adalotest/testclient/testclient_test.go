@@ -0,0 +1,32 @@
+package testclient
+
+import (
+	"context"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("persons", map[string]interface{}{"Name": "John"})
+	srv.AddUser("john@example.com")
+	ctx := context.Background()
+
+	client := New(srv)
+	var doc adalo.Document
+	assert.Nil(t, client.Collection("persons").GetContext(ctx, 1, &doc))
+	assert.Equal(t, "John", doc.String("Name"))
+	_, err := client.SendPushNotificationContext(ctx, &adalo.PushNotificationInput{
+		Audience:     adalo.PushNotificationAudienceInput{Email: "john@example.com"},
+		Notification: adalo.PushNotificationContentInput{Title: "Hello", Body: "World"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, srv.Notifications(), 1)
+
+	err = New(srv, adalo.WithRetryPolicy(adalo.NoRetries)).Collection("tasks").GetContext(ctx, 1, &doc)
+	assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+}
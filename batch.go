@@ -0,0 +1,214 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go/types"
+	"reflect"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of requests sent in parallel by batch operations unless configured otherwise.
+const DefaultBatchConcurrency = 5
+
+// ErrorBatchAborted is set for items of a batch that were not processed because
+// a previous item failed and BatchOptions.StopOnError was set.
+var ErrorBatchAborted = errors.New("batch aborted after a failed item")
+
+// BatchOptions configures InsertMany, UpdateMany and DeleteMany.
+type BatchOptions struct {
+	// Concurrency is the number of requests sent in parallel, defaults to DefaultBatchConcurrency.
+	// Requests are additionally delayed by the rate limiter of the client.
+	Concurrency int
+
+	// StopOnError stops sending further requests after the first failed item
+	StopOnError bool
+}
+
+// BatchUpdate is an update of a single record passed to UpdateMany.
+type BatchUpdate struct {
+	// ID of the record to update
+	ID int
+
+	// Input is sent as the update of the record
+	Input interface{}
+}
+
+// BatchItemResult is the result of a single item of a batch operation.
+type BatchItemResult struct {
+	// Index of the item in the passed inputs
+	Index int
+
+	// ID of the created, updated or deleted record, zero if the item failed
+	ID int
+
+	// Record is the raw created or updated record
+	Record json.RawMessage
+
+	// Err is the error the item failed with
+	Err error
+}
+
+// BatchResult is the result of a batch operation with one item per input in the order of the inputs.
+type BatchResult struct {
+	Items []BatchItemResult
+}
+
+// Succeeded returns the number of successful items.
+func (r *BatchResult) Succeeded() int {
+	return len(r.Items) - r.Failed()
+}
+
+// Failed returns the number of failed items.
+func (r *BatchResult) Failed() int {
+	failed := 0
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed++
+		}
+	}
+	return failed
+}
+
+// IDs returns the IDs of the records of all successful items.
+func (r *BatchResult) IDs() []int {
+	var ids []int
+	for _, item := range r.Items {
+		if item.Err == nil {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// Err returns a *BatchError combining the errors of all failed items, or nil if all items succeeded.
+func (r *BatchResult) Err() error {
+	var failures []BatchItemResult
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failures = append(failures, item)
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+	return &BatchError{Failures: failures, Total: len(r.Items)}
+}
+
+// BatchError is returned by batch operations when at least one item failed.
+type BatchError struct {
+	// Failures contains the results of the failed items
+	Failures []BatchItemResult
+
+	// Total is the number of items in the batch
+	Total int
+}
+
+// Error returns a summary of the failures and the error of the first failed item.
+func (e *BatchError) Error() string {
+	first := e.Failures[0]
+	return fmt.Sprintf("%d of %d batch items failed, item %d: %s", len(e.Failures), e.Total, first.Index, first.Err)
+}
+
+// Unwrap returns the errors of the failed items, so they can be inspected with errors.Is and errors.As.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// InsertMany inserts a record for each element of inputs, which must be a slice.
+// It returns a result for every input and a *BatchError if any insert failed.
+func (c *Collection) InsertMany(ctx context.Context, inputs interface{}, opts BatchOptions) (*BatchResult, error) {
+	items := reflect.ValueOf(inputs)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return nil, fmt.Errorf("inputs must be a slice, got %T", inputs)
+	}
+
+	return c.runBatch(ctx, items.Len(), opts, func(ctx context.Context, i int) (int, json.RawMessage, error) {
+		var record json.RawMessage
+		if err := c.InsertContext(ctx, items.Index(i).Interface(), &record); err != nil {
+			return 0, nil, err
+		}
+		id, err := types.RecordID(record)
+		return id, record, err
+	})
+}
+
+// UpdateMany applies the updates to the records of the collection.
+// It returns a result for every update and a *BatchError if any update failed.
+func (c *Collection) UpdateMany(ctx context.Context, updates []BatchUpdate, opts BatchOptions) (*BatchResult, error) {
+	return c.runBatch(ctx, len(updates), opts, func(ctx context.Context, i int) (int, json.RawMessage, error) {
+		var record json.RawMessage
+		if err := c.UpdateContext(ctx, updates[i].ID, updates[i].Input, &record); err != nil {
+			return 0, nil, err
+		}
+		return updates[i].ID, record, nil
+	})
+}
+
+// DeleteMany removes the records with the given ids from the collection.
+// It returns a result for every id and a *BatchError if any delete failed.
+func (c *Collection) DeleteMany(ctx context.Context, ids []int, opts BatchOptions) (*BatchResult, error) {
+	return c.runBatch(ctx, len(ids), opts, func(ctx context.Context, i int) (int, json.RawMessage, error) {
+		if err := c.DeleteContext(ctx, ids[i]); err != nil {
+			return 0, nil, err
+		}
+		return ids[i], nil, nil
+	})
+}
+
+// runBatch calls fn for the indexes 0 to n-1 with a bounded number of workers and collects the results.
+func (c *Collection) runBatch(ctx context.Context, n int, opts BatchOptions, fn func(ctx context.Context, i int) (int, json.RawMessage, error)) (*BatchResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	result := &BatchResult{Items: make([]BatchItemResult, n)}
+	indexes := make(chan int)
+
+	// requests in flight are completed when the batch is aborted, only pending items are skipped
+	var mu sync.Mutex
+	aborted := false
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				mu.Lock()
+				skip := aborted
+				mu.Unlock()
+
+				item := BatchItemResult{Index: i}
+				if skip {
+					item.Err = ErrorBatchAborted
+				} else {
+					item.ID, item.Record, item.Err = fn(ctx, i)
+				}
+
+				if item.Err != nil && opts.StopOnError {
+					mu.Lock()
+					aborted = true
+					mu.Unlock()
+				}
+				// each worker writes to distinct items
+				result.Items[i] = item
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return result, result.Err()
+}
@@ -0,0 +1,151 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// inFlightTransport delays requests and tracks the highest number of requests in flight at once.
+type inFlightTransport struct {
+	inFlight int32
+	max      int32
+}
+
+// RoundTrip sends the request with the default transport after a short delay.
+func (t *inFlightTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	current := atomic.AddInt32(&t.inFlight, 1)
+	defer atomic.AddInt32(&t.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&t.max)
+		if current <= max || atomic.CompareAndSwapInt32(&t.max, max, current) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newBatchCollection returns the persons collection of srv with a client whose transport tracks the requests in flight.
+func newBatchCollection(srv *adalotest.Server) (*Collection, *inFlightTransport) {
+	transport := &inFlightTransport{}
+	client := newTestClient(srv, WithHTTPClient(&http.Client{Transport: transport}))
+	return client.Collection("persons"), transport
+}
+
+func TestCollection_InsertMany(t *testing.T) {
+	t.Run("all successful", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons")
+		defer srv.Close()
+		collection, transport := newBatchCollection(srv)
+
+		inputs := make([]personInput, 20)
+		result, err := collection.InsertMany(context.Background(), inputs, BatchOptions{Concurrency: 3})
+
+		assert.Nil(t, err)
+		assert.Len(t, result.Items, 20)
+		assert.Equal(t, 20, result.Succeeded())
+		assert.Len(t, result.IDs(), 20)
+		assert.NotZero(t, result.Items[0].ID)
+		assert.NotEmpty(t, result.Items[0].Record)
+		assert.True(t, transport.max <= 3)
+		assert.True(t, transport.max > 1)
+	})
+
+	t.Run("with failed items", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons")
+		defer srv.Close()
+		collection, _ := newBatchCollection(srv)
+
+		// inputs that are not JSON objects are rejected by the server
+		inputs := []interface{}{personInput{Name: "John"}, "invalid", personInput{Name: "Jane"}, "invalid"}
+		result, err := collection.InsertMany(context.Background(), inputs, BatchOptions{})
+
+		var batchErr *BatchError
+		if assert.True(t, errors.As(err, &batchErr)) {
+			assert.Len(t, batchErr.Failures, 2)
+			assert.Equal(t, 4, batchErr.Total)
+			assert.Equal(t, "2 of 4 batch items failed, item 1: invalid json", err.Error())
+		}
+		assert.Equal(t, 2, result.Succeeded())
+		assert.Equal(t, 2, result.Failed())
+		assert.Nil(t, result.Items[0].Err)
+		assert.Error(t, result.Items[1].Err)
+		assert.Zero(t, result.Items[1].ID)
+
+		var apiErr *APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons")
+		defer srv.Close()
+		collection, _ := newBatchCollection(srv)
+
+		inputs := []interface{}{"invalid", personInput{Name: "John"}, personInput{Name: "Jane"}}
+		result, err := collection.InsertMany(context.Background(), inputs, BatchOptions{Concurrency: 1, StopOnError: true})
+
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, ErrorBatchAborted))
+		assert.Equal(t, 0, result.Succeeded())
+		assert.True(t, errors.Is(result.Items[2].Err, ErrorBatchAborted))
+	})
+
+	t.Run("with invalid inputs", func(t *testing.T) {
+		_, err := NewCollection("persons").InsertMany(context.Background(), personInput{}, BatchOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestCollection_UpdateMany(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", personInput{Name: "John"})
+	defer srv.Close()
+	collection, _ := newBatchCollection(srv)
+
+	result, err := collection.UpdateMany(context.Background(), []BatchUpdate{
+		{ID: 1, Input: personInput{Name: "John"}},
+		{ID: 13, Input: personInput{Name: "Jane"}},
+	}, BatchOptions{})
+
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, result.IDs())
+	assert.Error(t, result.Items[1].Err)
+}
+
+func TestCollection_DeleteMany(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", personInput{Name: "John"}, personInput{Name: "Jane"}, personInput{Name: "Richard"})
+	defer srv.Close()
+	collection, _ := newBatchCollection(srv)
+
+	persons := Typed[person, personInput](collection)
+	result, err := persons.DeleteMany(context.Background(), []int{1, 2, 3}, BatchOptions{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, result.IDs())
+	assert.Empty(t, srv.Records("persons"))
+}
+
+func TestBatchError_Unwrap(t *testing.T) {
+	apiErr := &APIError{StatusCode: http.StatusNotFound, Message: "Resource not found"}
+	err := &BatchError{Total: 3, Failures: []BatchItemResult{
+		{Index: 0, Err: ErrorBatchAborted},
+		{Index: 2, Err: fmt.Errorf("deleting: %w", apiErr)},
+	}}
+
+	assert.True(t, errors.Is(err, ErrorBatchAborted))
+	assert.False(t, errors.Is(err, ErrorConflict))
+
+	var target *APIError
+	assert.True(t, errors.As(err, &target))
+	assert.Same(t, apiErr, target)
+
+	var conflict *ConflictError
+	assert.False(t, errors.As(err, &conflict))
+}
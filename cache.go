@@ -0,0 +1,251 @@
+package adalo
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses kept by the cache of a client unless configured otherwise.
+const DefaultCacheSize = 1000
+
+// Cache stores raw responses of the Adalo API. Implementations must be safe for concurrent use.
+// Values passed to Set and returned by Get must not be modified.
+type Cache interface {
+	// Get returns the value stored for key and reports whether it was found and has not expired
+	Get(key string) ([]byte, bool)
+
+	// Set stores the value for key, which expires after ttl
+	Set(key string, value []byte, ttl time.Duration)
+
+	// Delete removes the value stored for key
+	Delete(key string)
+}
+
+// WithCache sets the Cache responses of cached collections are stored in, see Collection.Cached.
+// By default, each client uses a LRUCache with DefaultCacheSize entries. Passing nil disables caching.
+func WithCache(cache Cache) Option {
+	return func(cl *Client) {
+		cl.cache = newResponseCache(cache)
+	}
+}
+
+// CacheStats counts the lookups of cached collections in the cache of a client.
+type CacheStats struct {
+	// Hits is the number of responses served from the cache
+	Hits uint64
+
+	// Misses is the number of responses fetched from the API because they were not cached
+	Misses uint64
+}
+
+// HitRatio returns the share of lookups served from the cache, or 0 if there were no lookups.
+func (s CacheStats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// CacheStats returns the hit and miss counts of the cache of the client.
+func (cl *Client) CacheStats() CacheStats {
+	if cl.cache == nil {
+		return CacheStats{}
+	}
+	return CacheStats{Hits: cl.cache.hits.Load(), Misses: cl.cache.misses.Load()}
+}
+
+// Cached returns a copy of the collection whose Get, List, Iterate and All responses are cached for ttl
+// in the cache of its client. Insert, Update and Delete on any copy of the collection invalidate the cached
+// lists of the collection, Update and Delete additionally replace or remove the cached record.
+// Changes made by other clients or in the Adalo app become visible once the cached responses expire.
+func (c *Collection) Cached(ttl time.Duration) *Collection {
+	cached := *c
+	cached.cacheTTL = ttl
+	return &cached
+}
+
+// get performs a GET request, serving the response from the cache if the collection is cached.
+func (c *Collection) get(ctx context.Context, key, url string) ([]byte, error) {
+	cache := c.apiClient().cache
+	if c.cacheTTL <= 0 || cache == nil {
+		_, body, err := c.apiClient().do(ctx, http.MethodGet, url, nil)
+		return body, err
+	}
+
+	if body, ok := cache.Get(key); ok {
+		cache.hits.Add(1)
+		return body, nil
+	}
+	cache.misses.Add(1)
+
+	_, body, err := c.apiClient().do(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	cache.Set(key, body, c.cacheTTL)
+	return body, nil
+}
+
+// recordCacheKey returns the cache key of the record with the given id.
+func (c *Collection) recordCacheKey(id int) string {
+	return c.recordURL(id)
+}
+
+// listCacheKey returns the cache key of a page of records listed with the query.
+// The key contains the generation of the collection, so writes invalidate all cached lists.
+func (c *Collection) listCacheKey(query string) string {
+	base := c.collectionAPIBaseURL()
+	generation := uint64(0)
+	if cache := c.apiClient().cache; cache != nil {
+		generation = cache.generation(base)
+	}
+	return fmt.Sprintf("%s?%s#%d", base, query, generation)
+}
+
+// invalidate invalidates the cached lists of the collection after a write. The cached record with the given id
+// is replaced with record, or removed if record is nil. An id of 0 leaves the cached records untouched.
+func (c *Collection) invalidate(id int, record []byte) {
+	cache := c.apiClient().cache
+	if cache == nil {
+		return
+	}
+
+	cache.nextGeneration(c.collectionAPIBaseURL())
+	if id == 0 {
+		return
+	}
+	if record != nil && c.cacheTTL > 0 {
+		cache.Set(c.recordCacheKey(id), record, c.cacheTTL)
+		return
+	}
+	cache.Delete(c.recordCacheKey(id))
+}
+
+// responseCache wraps the Cache of a client with statistics and the generations of the cached lists.
+type responseCache struct {
+	Cache
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+
+	mu          sync.Mutex
+	generations map[string]uint64
+}
+
+// newResponseCache returns a responseCache storing responses in cache, or nil if cache is nil.
+func newResponseCache(cache Cache) *responseCache {
+	if cache == nil {
+		return nil
+	}
+	return &responseCache{Cache: cache, generations: map[string]uint64{}}
+}
+
+// generation returns the current generation of the lists of the collection.
+func (rc *responseCache) generation(collection string) uint64 {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.generations[collection]
+}
+
+// nextGeneration starts a new generation of the lists of the collection, so all cached lists become unreachable.
+func (rc *responseCache) nextGeneration(collection string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generations[collection]++
+}
+
+// LRUCache is an in-memory Cache that evicts the least recently used entries when it is full.
+type LRUCache struct {
+	size int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// lruEntry is an entry of a LRUCache.
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache returns a LRUCache holding up to size entries.
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &LRUCache{size: size, entries: map[string]*list.Element{}, order: list.New(), now: time.Now}
+}
+
+// Get returns the value stored for key and reports whether it was found and has not expired.
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.value, true
+}
+
+// Set stores the value for key, which expires after ttl. A ttl of zero or less never expires.
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes the value stored for key.
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that were not evicted yet.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// remove removes the element from the cache. The caller must hold c.mu.
+func (c *LRUCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,167 @@
+package adalo
+
+import (
+	"context"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// cachePersons are the records of the persons collection in the cache tests.
+var cachePersons = []interface{}{personInput{Name: "John", Age: 21}, personInput{Name: "Jane", Age: 28}}
+
+func TestCollection_Cached(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("caches get and list", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", cachePersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+		persons := Typed[person, personInput](client.Collection("persons").Cached(time.Minute))
+
+		for i := 0; i < 3; i++ {
+			result, err := persons.Get(ctx, 1)
+			assert.Nil(t, err)
+			assert.Equal(t, "John", result.Name)
+
+			all, err := persons.All(ctx)
+			assert.Nil(t, err)
+			assert.Len(t, all, 2)
+		}
+		assert.Equal(t, 2, srv.CountRequests(http.MethodGet, ""))
+		assert.Equal(t, CacheStats{Hits: 4, Misses: 2}, client.CacheStats())
+		assert.InDelta(t, 0.666, client.CacheStats().HitRatio(), 0.001)
+
+		// filtered lists are cached separately
+		filtered, err := persons.Where("Name", "Jane").All(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, filtered, 1)
+		assert.Equal(t, 3, srv.CountRequests(http.MethodGet, ""))
+	})
+
+	t.Run("uncached collections are not cached", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", cachePersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+		persons := client.Collection("persons")
+
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Equal(t, 2, srv.CountRequests(http.MethodGet, ""))
+		assert.Equal(t, CacheStats{}, client.CacheStats())
+	})
+
+	t.Run("writes invalidate", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", cachePersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+		persons := Typed[person, personInput](client.Collection("persons").Cached(time.Minute))
+		uncached := Typed[person, personInput](client.Collection("persons"))
+
+		_, err := persons.All(ctx)
+		assert.Nil(t, err)
+		_, err = persons.Get(ctx, 1)
+		assert.Nil(t, err)
+
+		// update through another copy of the collection
+		_, err = uncached.Update(ctx, 1, personInput{Name: "Johnny", Age: 22})
+		assert.Nil(t, err)
+		result, err := persons.Get(ctx, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "Johnny", result.Name)
+		all, err := persons.All(ctx)
+		assert.Nil(t, err)
+		assert.Equal(t, "Johnny", all[0].Name)
+
+		// write-through of updated records
+		srv.ResetRequests()
+		_, err = persons.Update(ctx, 2, personInput{Name: "Janet", Age: 29})
+		assert.Nil(t, err)
+		result, err = persons.Get(ctx, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, "Janet", result.Name)
+		assert.Equal(t, 0, srv.CountRequests(http.MethodGet, ""))
+
+		inserted, err := persons.Insert(ctx, personInput{Name: "Richard", Age: 89})
+		assert.Nil(t, err)
+		all, err = persons.All(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, all, 3)
+
+		assert.Nil(t, persons.DeleteRecord(ctx, inserted))
+		all, err = persons.All(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, all, 2)
+		_, err = persons.Get(ctx, inserted.ID)
+		assert.Error(t, err)
+	})
+
+	t.Run("expires", func(t *testing.T) {
+		cache := NewLRUCache(10)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", cachePersons...)
+		defer srv.Close()
+		client := newTestClient(srv, WithCache(cache))
+		persons := client.Collection("persons").Cached(time.Second)
+
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, ""))
+
+		now = now.Add(time.Second)
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Equal(t, 2, srv.CountRequests(http.MethodGet, ""))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", cachePersons...)
+		defer srv.Close()
+		client := newTestClient(srv, WithCache(nil))
+		persons := client.Collection("persons").Cached(time.Minute)
+
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Nil(t, persons.Get(1, nil))
+		assert.Nil(t, persons.Update(1, personInput{Name: "John"}, nil))
+		assert.Equal(t, 2, srv.CountRequests(http.MethodGet, ""))
+	})
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), 0)
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, "1", string(value))
+
+	// b is the least recently used entry
+	cache.Set("c", []byte("3"), time.Minute)
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+
+	cache.Set("a", []byte("4"), time.Minute)
+	value, _ = cache.Get("a")
+	assert.Equal(t, "4", string(value))
+
+	now = now.Add(time.Minute)
+	_, ok = cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 1, cache.Len())
+
+	cache.Delete("c")
+	assert.Equal(t, 0, cache.Len())
+
+	for i := 0; i < 5; i++ {
+		cache.Set(strconv.Itoa(i), nil, 0)
+	}
+	assert.Equal(t, 2, cache.Len())
+}
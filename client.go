@@ -0,0 +1,266 @@
+package adalo
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultCollectionsBaseURL is the base url for collection api calls on Adalo.
+	defaultCollectionsBaseURL = "http://api.adalo.com"
+
+	// defaultNotificationsBaseURL is the base url for push notification api calls on Adalo.
+	defaultNotificationsBaseURL = "https://api.adalo.com"
+)
+
+// Client performs requests to the Adalo API on behalf of a single Adalo app.
+// It is safe for concurrent use by multiple goroutines.
+type Client struct {
+	// apiKey is the Adalo API key used to authenticate requests
+	apiKey string
+
+	// appID is the ID of the Adalo app requests are performed for
+	appID string
+
+	// global indicates that the credentials are read from the package-level
+	// ApiKey and AppID on every request
+	global bool
+
+	// collectionsBaseURL is the base url for collection api calls
+	collectionsBaseURL string
+
+	// notificationsBaseURL is the base url for push notification api calls
+	notificationsBaseURL string
+
+	// httpClient is used to send all requests
+	httpClient *http.Client
+
+	// transport, proxy and tlsConfig are applied to the transport of httpClient by NewClient
+	transport http.RoundTripper
+	proxy     func(*http.Request) (*url.URL, error)
+	tlsConfig *tls.Config
+
+	// retryPolicy configures how failed requests are retried
+	retryPolicy RetryPolicy
+
+	// rateLimiter delays requests exceeding the rate limit, no limit is applied when nil
+	rateLimiter *RateLimiter
+
+	// cache stores the responses of cached collections, nothing is cached when nil
+	cache *responseCache
+}
+
+// Option configures a Client created with NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client used to send requests to the Adalo API.
+// Passing nil restores the default client.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(cl *Client) {
+		if httpClient == nil {
+			httpClient = &http.Client{}
+		}
+		cl.httpClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests to the Adalo API.
+// It takes precedence over the transport of a http.Client set with WithHTTPClient.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(cl *Client) {
+		cl.transport = transport
+	}
+}
+
+// WithProxy sets the function that determines the proxy for a request, e.g. http.ProxyURL.
+// It only takes effect when the client sends requests through a *http.Transport.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) Option {
+	return func(cl *Client) {
+		cl.proxy = proxy
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for requests to the Adalo API.
+// It only takes effect when the client sends requests through a *http.Transport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(cl *Client) {
+		cl.tlsConfig = config
+	}
+}
+
+// WithCollectionsBaseURL overrides the base url for collection api calls, which defaults to http://api.adalo.com.
+// Requests are sent to <baseURL>/apps/<app-id>/collections/<collection-id>.
+func WithCollectionsBaseURL(baseURL string) Option {
+	return func(cl *Client) {
+		cl.collectionsBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithNotificationsBaseURL overrides the base url for push notification api calls, which defaults to https://api.adalo.com.
+// Requests are sent to <baseURL>/notifications.
+func WithNotificationsBaseURL(baseURL string) Option {
+	return func(cl *Client) {
+		cl.notificationsBaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// NewClient initializes a Client for the Adalo app with the given ID.
+func NewClient(apiKey, appID string, opts ...Option) *Client {
+	cl := &Client{
+		apiKey:               apiKey,
+		appID:                appID,
+		collectionsBaseURL:   defaultCollectionsBaseURL,
+		notificationsBaseURL: defaultNotificationsBaseURL,
+		httpClient:           &http.Client{},
+		retryPolicy:          DefaultRetryPolicy,
+		rateLimiter:          NewRateLimiter(DefaultRateLimit, DefaultRateLimitBurst),
+		cache:                newResponseCache(NewLRUCache(DefaultCacheSize)),
+	}
+	for _, opt := range opts {
+		opt(cl)
+	}
+	cl.configureTransport()
+	return cl
+}
+
+// configureTransport applies the transport, proxy and TLS options to a copy of the http client,
+// so a http.Client passed with WithHTTPClient is never modified.
+func (cl *Client) configureTransport() {
+	if cl.transport == nil && cl.proxy == nil && cl.tlsConfig == nil {
+		return
+	}
+
+	transport := cl.transport
+	if transport == nil {
+		transport = cl.httpClient.Transport
+	}
+
+	if cl.proxy != nil || cl.tlsConfig != nil {
+		var t *http.Transport
+		switch base := transport.(type) {
+		case nil:
+			t = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			t = base.Clone()
+		}
+		if t != nil {
+			if cl.proxy != nil {
+				t.Proxy = cl.proxy
+			}
+			if cl.tlsConfig != nil {
+				t.TLSClientConfig = cl.tlsConfig
+			}
+			transport = t
+		}
+	}
+
+	httpClient := *cl.httpClient
+	httpClient.Transport = transport
+	cl.httpClient = &httpClient
+}
+
+// defaultClient is the Client used by NewCollection and SendPushNotification.
+// It reads its credentials from the package-level ApiKey and AppID.
+var defaultClient = &Client{
+	global:               true,
+	collectionsBaseURL:   defaultCollectionsBaseURL,
+	notificationsBaseURL: defaultNotificationsBaseURL,
+	httpClient:           &http.Client{},
+	retryPolicy:          DefaultRetryPolicy,
+	rateLimiter:          NewRateLimiter(DefaultRateLimit, DefaultRateLimitBurst),
+	cache:                newResponseCache(NewLRUCache(DefaultCacheSize)),
+}
+
+// AppID returns the ID of the Adalo app the client performs requests for.
+func (cl *Client) AppID() string {
+	if cl.global {
+		return AppID
+	}
+	return cl.appID
+}
+
+// authorization returns the value of the Authorization header sent with each request.
+func (cl *Client) authorization() string {
+	if cl.global {
+		return fmt.Sprintf("Bearer %s", ApiKey)
+	}
+	return fmt.Sprintf("Bearer %s", cl.apiKey)
+}
+
+// Collection initializes a Collection whose requests are performed by this client.
+func (cl *Client) Collection(collectionID string) *Collection {
+	return &Collection{ID: collectionID, client: cl}
+}
+
+// do sends an authenticated request to the Adalo API and returns the response together with its body.
+// If input is not nil, it is marshaled and sent as the JSON request body.
+// An *APIError is returned if the API responded with an error.
+// Failed requests are retried according to the RetryPolicy of the client.
+// The request is cancelled as soon as ctx is done.
+func (cl *Client) do(ctx context.Context, method, url string, input interface{}) (*http.Response, []byte, error) {
+	var payload []byte
+	if input != nil {
+		inputBytes, err := json.Marshal(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		payload = inputBytes
+	}
+
+	for attempts := 1; ; attempts++ {
+		res, body, err := cl.send(ctx, method, url, payload)
+		if err == nil || !cl.retryPolicy.shouldRetry(method, attempts, err) {
+			return res, body, err
+		}
+
+		timer := time.NewTimer(cl.retryPolicy.backoff(attempts, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// send performs a single attempt of a request sent by do once the rate limiter allows it.
+func (cl *Client) send(ctx context.Context, method, url string, payload []byte) (*http.Response, []byte, error) {
+	if err := cl.rateLimiter.Wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Add("Authorization", cl.authorization())
+	req.Header.Add("Content-Type", "application/json")
+
+	res, err := cl.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	resBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res, resBody, newAPIError(req, res, resBody)
+}
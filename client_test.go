@@ -0,0 +1,154 @@
+package adalo
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newRecordingServer starts a test server that answers every request with an empty record
+// and stores the last received request in the passed variable.
+func newRecordingServer(last **http.Request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = r
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "successful": 1}`))
+	}))
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("uses own credentials", func(t *testing.T) {
+		var last *http.Request
+		srv := newRecordingServer(&last)
+		defer srv.Close()
+
+		clientA := NewClient("key-a", "app-a", WithCollectionsBaseURL(srv.URL))
+		clientB := NewClient("key-b", "app-b", WithCollectionsBaseURL(srv.URL))
+
+		assert.Nil(t, clientA.Collection("persons").Get(1, nil))
+		assert.Equal(t, "/apps/app-a/collections/persons/1", last.URL.Path)
+		assert.Equal(t, "Bearer key-a", last.Header.Get("Authorization"))
+
+		assert.Nil(t, clientB.Collection("persons").Get(1, nil))
+		assert.Equal(t, "/apps/app-b/collections/persons/1", last.URL.Path)
+		assert.Equal(t, "Bearer key-b", last.Header.Get("Authorization"))
+	})
+
+	t.Run("sends push notifications with client app id", func(t *testing.T) {
+		var last *http.Request
+		srv := newRecordingServer(&last)
+		defer srv.Close()
+
+		client := NewClient("key-a", "app-a", WithNotificationsBaseURL(srv.URL+"/"))
+
+		input := &PushNotificationInput{}
+		i, err := client.SendPushNotification(input)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, i)
+		assert.Equal(t, "/notifications", last.URL.Path)
+		assert.Nil(t, input.AppID)
+	})
+
+	t.Run("with http client", func(t *testing.T) {
+		httpClient := &http.Client{}
+		client := NewClient("key", "app", WithHTTPClient(httpClient))
+		assert.Same(t, httpClient, client.httpClient)
+	})
+
+	t.Run("with nil http client", func(t *testing.T) {
+		var last *http.Request
+		srv := newRecordingServer(&last)
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithHTTPClient(nil), WithTLSConfig(&tls.Config{}), WithCollectionsBaseURL(srv.URL))
+		assert.NotNil(t, client.httpClient)
+		assert.Nil(t, client.Collection("persons").Get(1, nil))
+	})
+
+	t.Run("with transport", func(t *testing.T) {
+		var last *http.Request
+		srv := newRecordingServer(&last)
+		defer srv.Close()
+
+		var used bool
+		transport := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(req)
+		})
+		httpClient := &http.Client{}
+		client := NewClient("key", "app", WithHTTPClient(httpClient), WithTransport(transport), WithCollectionsBaseURL(srv.URL))
+
+		assert.Nil(t, client.Collection("persons").Get(1, nil))
+		assert.True(t, used)
+		assert.Nil(t, httpClient.Transport)
+	})
+
+	t.Run("with proxy and tls config", func(t *testing.T) {
+		proxyURL, _ := url.Parse("http://proxy.internal:3128")
+		tlsConfig := &tls.Config{ServerName: "api.adalo.com"}
+		client := NewClient("key", "app", WithProxy(http.ProxyURL(proxyURL)), WithTLSConfig(tlsConfig))
+
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		if assert.True(t, ok) {
+			assert.Same(t, tlsConfig, transport.TLSClientConfig)
+			u, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "api.adalo.com"}})
+			assert.Nil(t, err)
+			assert.Equal(t, proxyURL, u)
+		}
+		assert.NotSame(t, http.DefaultTransport, transport)
+	})
+}
+
+// roundTripperFunc is an adapter to use ordinary functions as http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestDefaultClient(t *testing.T) {
+	var last *http.Request
+	srv := newRecordingServer(&last)
+	defer srv.Close()
+
+	defer func(url string) { defaultClient.collectionsBaseURL = url }(defaultClient.collectionsBaseURL)
+	defaultClient.collectionsBaseURL = srv.URL
+
+	ApiKey, AppID = "global-key", "global-app"
+	defer setup()
+
+	assert.Nil(t, NewCollection("persons").Get(1, nil))
+	assert.Equal(t, "/apps/global-app/collections/persons/1", last.URL.Path)
+	assert.Equal(t, "Bearer global-key", last.Header.Get("Authorization"))
+}
+
+func TestClient_Context(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithNotificationsBaseURL(srv.URL))
+
+	t.Run("cancelled collection request", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := client.Collection("persons").GetContext(ctx, 1, nil)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("cancelled push notification", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := client.SendPushNotificationContext(ctx, &PushNotificationInput{})
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"go/format"
+	"go/token"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// goTypes maps the Adalo field types to the Go types used in generated structs.
+var goTypes = map[adalo.FieldType]string{
+	adalo.FieldText:         "string",
+	adalo.FieldNumber:       "types.Number",
+	adalo.FieldBoolean:      "types.Bool",
+	adalo.FieldDate:         "types.Date",
+	adalo.FieldDateTime:     "types.DateTime",
+	adalo.FieldImage:        "types.Image",
+	adalo.FieldFile:         "types.File",
+	adalo.FieldLocation:     "types.Location",
+	adalo.FieldRelationship: "types.Relation",
+	adalo.FieldJSON:         "json.RawMessage",
+}
+
+// scalarTypes are the types of the types package whose zero value is a valid value of the field, e.g. 0 or false.
+var scalarTypes = map[string]bool{"types.Number": true, "types.Bool": true}
+
+// typesType reports whether goType, or the type it points to, is a type of the types package.
+func typesType(goType string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(goType, "*"), "types.")
+}
+
+// richType reports whether goType is a type of the types package, whose zero value represents an empty field.
+func richType(goType string) bool {
+	return typesType(goType) && !scalarTypes[goType]
+}
+
+// rawType reports whether goType is json.RawMessage, whose nil value represents an empty field.
+func rawType(goType string) bool {
+	return goType == "json.RawMessage"
+}
+
+// reservedNames are the names of the fields and methods promoted from the embedded adalo.Record.
+var reservedNames = map[string]bool{"Record": true, "ID": true, "CreatedAt": true, "UpdatedAt": true, "RecordID": true}
+
+// collection is the data passed to the template for each collection in the schema.
+type collection struct {
+	Name   string
+	ID     string
+	Fields []field
+}
+
+// field is the data passed to the template for each field of a collection.
+type field struct {
+	Name   string
+	GoName string
+	GoType string
+}
+
+// JSONTag returns the struct tag of the field.
+func (f field) JSONTag() string {
+	return fmt.Sprintf("`json:%q`", f.Name)
+}
+
+// InputTag returns the struct tag of the field in the input struct,
+// which omits empty values that have no meaningful zero value.
+func (f field) InputTag() string {
+	if strings.HasPrefix(f.GoType, "*") || rawType(f.GoType) {
+		return fmt.Sprintf("`json:\"%s,omitempty\"`", f.Name)
+	}
+	if richType(f.GoType) {
+		return fmt.Sprintf("`json:\"%s,omitzero\"`", f.Name)
+	}
+	return f.JSONTag()
+}
+
+// generate returns the formatted Go source code for the collections in the schema.
+func generate(schema *adalo.Schema, pkg string) ([]byte, error) {
+	if err := schema.Validate(); err != nil {
+		return nil, err
+	}
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+
+	var collections []collection
+	usesTypes, usesJSON := false, false
+	for _, c := range schema.Collections {
+		if !token.IsIdentifier(c.Name) || !token.IsExported(c.Name) {
+			return nil, fmt.Errorf("collection %s: name must be an exported Go identifier", c.Name)
+		}
+
+		generated := collection{Name: c.Name, ID: c.ID}
+		goNames := map[string]bool{}
+		for _, f := range c.Fields {
+			if !validTagName(f.Name) {
+				return nil, fmt.Errorf("collection %s: field %s: name cannot be used as json tag", c.Name, f.Name)
+			}
+
+			goName := f.GoName
+			if goName == "" {
+				goName = goIdentifier(f.Name)
+			}
+			if !token.IsIdentifier(goName) || !token.IsExported(goName) {
+				return nil, fmt.Errorf("collection %s: field %s: %q is not an exported Go identifier", c.Name, f.Name, goName)
+			}
+			if reservedNames[goName] || goNames[goName] {
+				return nil, fmt.Errorf("collection %s: field %s: Go name %s is already used, set goName", c.Name, f.Name, goName)
+			}
+			goNames[goName] = true
+
+			goType := goTypes[f.Type]
+			if f.Nullable && !richType(goType) && !rawType(goType) {
+				goType = "*" + goType
+			}
+			usesTypes = usesTypes || typesType(goType)
+			usesJSON = usesJSON || rawType(goType)
+			generated.Fields = append(generated.Fields, field{Name: f.Name, GoName: goName, GoType: goType})
+		}
+		collections = append(collections, generated)
+	}
+
+	var buf bytes.Buffer
+	err := sourceTemplate.Execute(&buf, struct {
+		Package     string
+		UsesTypes   bool
+		UsesJSON    bool
+		Collections []collection
+	}{pkg, usesTypes, usesJSON, collections})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// goIdentifier converts the name of an Adalo field to an exported Go identifier, e.g. "Date of Birth" to DateOfBirth.
+func goIdentifier(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteString("F")
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// validTagName reports whether name can be used as name in a json struct tag.
+func validTagName(name string) bool {
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
+
+// sourceTemplate is the template of the generated Go source code.
+var sourceTemplate = template.Must(template.New("source").Parse(`// Code generated by adalo-gen. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+{{- if .UsesJSON }}
+	"encoding/json"
+{{- end }}
+	"github.com/be-foo/adalo-sdk-go"
+{{- if .UsesTypes }}
+	"github.com/be-foo/adalo-sdk-go/types"
+{{- end }}
+)
+{{ range .Collections }}
+// {{ .Name }}CollectionID is the ID of the {{ .Name }} collection in Adalo.
+const {{ .Name }}CollectionID = {{ printf "%q" .ID }}
+
+// {{ .Name }} represents a record in the {{ .Name }} collection in Adalo.
+type {{ .Name }} struct {
+	adalo.Record
+{{ range .Fields }}
+	// {{ .GoName }} is the {{ printf "%q" .Name }} field
+	{{ .GoName }} {{ .GoType }} {{ .JSONTag }}
+{{ end -}}
+}
+
+// {{ .Name }}Input represents the schema for inputting a {{ .Name }} in the Adalo collection.
+type {{ .Name }}Input struct {
+{{- range .Fields }}
+	// {{ .GoName }} is the {{ printf "%q" .Name }} field
+	{{ .GoName }} {{ .GoType }} {{ .InputTag }}
+{{ end -}}
+}
+
+// {{ .Name }}Collection returns a TypedCollection for the {{ .Name }} collection that performs requests with the client.
+// If client is nil, the global ApiKey and AppID are used.
+func {{ .Name }}Collection(client *adalo.Client) *adalo.TypedCollection[{{ .Name }}, {{ .Name }}Input] {
+	if client == nil {
+		return adalo.NewTypedCollection[{{ .Name }}, {{ .Name }}Input]({{ .Name }}CollectionID)
+	}
+	return adalo.Typed[{{ .Name }}, {{ .Name }}Input](client.Collection({{ .Name }}CollectionID))
+}
+{{ end -}}
+`))
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	schema, err := adalo.ReadSchema("testdata/schema.yaml")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	source, err := generate(schema, "models")
+	assert.Nil(t, err)
+
+	assert.Nil(t, typeCheck(source))
+
+	code := string(source)
+	assert.Contains(t, code, "// Code generated by adalo-gen. DO NOT EDIT.")
+	assert.Contains(t, code, "package models")
+	assert.Contains(t, code, `const PersonCollectionID = "t_person"`)
+	assert.Contains(t, code, "type Person struct {\n\tadalo.Record\n")
+	assert.Contains(t, code, "Name string `json:\"Name\"`")
+	assert.Contains(t, code, "Age types.Number `json:\"Age\"`")
+	assert.Contains(t, code, "Score *types.Number `json:\"Score\"`")
+	assert.Contains(t, code, "Score *types.Number `json:\"Score,omitempty\"`")
+	assert.Contains(t, code, `"github.com/be-foo/adalo-sdk-go/types"`)
+	assert.Contains(t, code, "DateOfBirth types.Date `json:\"Date of Birth\"`")
+	assert.Contains(t, code, "DateOfBirth types.Date `json:\"Date of Birth,omitzero\"`")
+	assert.Contains(t, code, "Nickname *string `json:\"Nickname\"`")
+	assert.Contains(t, code, "Nickname *string `json:\"Nickname,omitempty\"`")
+	assert.Contains(t, code, "Avatar types.Image `json:\"Avatar\"`")
+	assert.Contains(t, code, "IsAdmin types.Bool `json:\"Is Admin?\"`")
+	assert.Contains(t, code, "Tasks types.Relation `json:\"Tasks\"`")
+	assert.Contains(t, code, "Tasks types.Relation `json:\"Tasks,omitzero\"`")
+	assert.Contains(t, code, "Completed types.Bool `json:\"Done\"`")
+	assert.Contains(t, code, `"encoding/json"`)
+	assert.Contains(t, code, "Tags json.RawMessage `json:\"Tags\"`")
+	assert.Contains(t, code, "Tags json.RawMessage `json:\"Tags,omitempty\"`")
+	assert.Contains(t, code, "func PersonCollection(client *adalo.Client) *adalo.TypedCollection[Person, PersonInput] {")
+	assert.Contains(t, code, "func TaskCollection(client *adalo.Client) *adalo.TypedCollection[Task, TaskInput] {")
+}
+
+// typeCheck parses and type checks the generated source, importing its dependencies from source.
+func typeCheck(source []byte) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "adalo_gen.go", source, parser.AllErrors)
+	if err != nil {
+		return err
+	}
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("models", fset, []*ast.File{file}, nil)
+	return err
+}
+
+func TestGenerate_errors(t *testing.T) {
+	tests := map[string]adalo.CollectionSchema{
+		"unexported name": {Name: "person", ID: "t_1"},
+		"invalid type":    {Name: "Person", ID: "t_1", Fields: []adalo.FieldSchema{{Name: "Name", Type: "string"}}},
+		"reserved name":   {Name: "Person", ID: "t_1", Fields: []adalo.FieldSchema{{Name: "ID", Type: adalo.FieldText}}},
+		"embedded name":   {Name: "Person", ID: "t_1", Fields: []adalo.FieldSchema{{Name: "Record", Type: adalo.FieldText}}},
+		"duplicate name":  {Name: "Person", ID: "t_1", Fields: []adalo.FieldSchema{{Name: "a b", Type: adalo.FieldText}, {Name: "A B", Type: adalo.FieldText}}},
+		"invalid tag":     {Name: "Person", ID: "t_1", Fields: []adalo.FieldSchema{{Name: `Say "Hi"`, Type: adalo.FieldText}}},
+		"missing id":      {Name: "Person"},
+	}
+	for name, collection := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := generate(&adalo.Schema{Collections: []adalo.CollectionSchema{collection}}, "models")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGoIdentifier(t *testing.T) {
+	assert.Equal(t, "DateOfBirth", goIdentifier("Date of Birth"))
+	assert.Equal(t, "EMail", goIdentifier("e-mail"))
+	assert.Equal(t, "F2FactorEnabled", goIdentifier("2 factor enabled"))
+	assert.Equal(t, "IsAdmin", goIdentifier("Is Admin?"))
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	schema := filepath.Join(dir, "schema.json")
+	out := filepath.Join(dir, "adalo_gen.go")
+	err := os.WriteFile(schema, []byte(`{"collections": [{"name": "Person", "id": "t_1", "fields": [{"name": "Name", "type": "text"}]}]}`), 0644)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	t.Setenv("GOPACKAGE", "models")
+	assert.Nil(t, run([]string{"-schema", schema, "-out", out}))
+
+	source, err := os.ReadFile(out)
+	assert.Nil(t, err)
+	assert.Contains(t, string(source), "package models")
+	assert.Nil(t, typeCheck(source))
+	assert.NotContains(t, string(source), "adalo-sdk-go/types")
+
+	assert.Error(t, run([]string{"-out", out}))
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"gopkg.in/yaml.v3"
+	"os"
+	"strings"
+)
+
+// collectionFlags collects the values of the repeatable -collection flag in the format Name=ID.
+type collectionFlags []adalo.CollectionSchema
+
+// String returns the flag values in the format they were passed.
+func (c *collectionFlags) String() string {
+	var values []string
+	for _, collection := range *c {
+		values = append(values, collection.Name+"="+collection.ID)
+	}
+	return strings.Join(values, ",")
+}
+
+// Set adds a collection passed as Name=ID.
+func (c *collectionFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("collection must be passed as Name=ID, got %q", value)
+	}
+	*c = append(*c, adalo.CollectionSchema{Name: parts[0], ID: parts[1]})
+	return nil
+}
+
+// runInfer infers a schema from the records of the collections passed as command line arguments.
+func runInfer(args []string) error {
+	var collections collectionFlags
+	flags := flag.NewFlagSet("adalo-gen infer", flag.ContinueOnError)
+	flags.Var(&collections, "collection", "collection to scan as Name=ID, can be repeated (required)")
+	apiKey := flags.String("api-key", os.Getenv("ADALO_API_KEY"), "Adalo API key, defaults to $ADALO_API_KEY")
+	appID := flags.String("app-id", os.Getenv("ADALO_APP_ID"), "Adalo app ID, defaults to $ADALO_APP_ID")
+	baseURL := flags.String("base-url", "", "overrides the base url of the collections API")
+	pkg := flags.String("package", "", "package name written to the schema")
+	format := flags.String("format", "", "format of the schema, yaml or json, defaults to the extension of -out or yaml")
+	out := flags.String("out", "", "path to the schema file, prints to stdout if empty")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(collections) == 0 {
+		flags.Usage()
+		return fmt.Errorf("flag -collection is required")
+	}
+	if *apiKey == "" || *appID == "" {
+		return fmt.Errorf("api key and app id are required, set $ADALO_API_KEY and $ADALO_APP_ID")
+	}
+
+	var opts []adalo.Option
+	if *baseURL != "" {
+		opts = append(opts, adalo.WithCollectionsBaseURL(*baseURL))
+	}
+	client := adalo.NewClient(*apiKey, *appID, opts...)
+
+	schema := adalo.Schema{Package: *pkg}
+	for _, collection := range collections {
+		inferred, err := adalo.InferSchema(context.Background(), client.Collection(collection.ID))
+		if err != nil {
+			return fmt.Errorf("collection %s: %w", collection.Name, err)
+		}
+		inferred.Name = collection.Name
+		schema.Collections = append(schema.Collections, *inferred)
+	}
+
+	if *format == "" && strings.HasSuffix(strings.ToLower(*out), ".json") {
+		*format = "json"
+	}
+
+	var data []byte
+	var err error
+	switch *format {
+	case "json":
+		data, err = json.MarshalIndent(schema, "", "  ")
+		data = append(data, '\n')
+	case "", "yaml":
+		data, err = yaml.Marshal(schema)
+	default:
+		return fmt.Errorf("invalid format %q", *format)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*out, data)
+}
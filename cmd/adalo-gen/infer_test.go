@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunInfer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		records := []map[string]interface{}{}
+		if r.URL.Query().Get("offset") == "0" {
+			records = append(records, map[string]interface{}{"id": 1, "Full Name": "John", "Age": 21, "Tasks": []int{1}})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"records": records})
+	}))
+	defer srv.Close()
+
+	for _, ext := range []string{".yaml", ".json"} {
+		t.Run(ext, func(t *testing.T) {
+			out := filepath.Join(t.TempDir(), "schema"+ext)
+			err := run([]string{"infer", "-api-key", "key", "-app-id", "app", "-base-url", srv.URL,
+				"-package", "models", "-collection", "Person=t_person", "-out", out})
+			if !assert.Nil(t, err) {
+				return
+			}
+
+			schema, err := adalo.ReadSchema(out)
+			if !assert.Nil(t, err) {
+				return
+			}
+			assert.Equal(t, "models", schema.Package)
+			assert.Equal(t, "Person", schema.Collections[0].Name)
+			assert.Equal(t, "t_person", schema.Collections[0].ID)
+			assert.Len(t, schema.Collections[0].Fields, 3)
+
+			// the inferred schema can be used to generate code right away
+			_, err = generate(schema, schema.Package)
+			assert.Nil(t, err)
+		})
+	}
+
+	t.Run("invalid collection flag", func(t *testing.T) {
+		err := run([]string{"infer", "-api-key", "key", "-app-id", "app", "-collection", "Person"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,105 @@
+// Command adalo-gen generates Go types and typed collections from a schema file
+// describing the collections of an Adalo app.
+//
+// The schema is a YAML or JSON document as described by adalo.Schema:
+//
+//	package: models
+//	collections:
+//	  - name: Person
+//	    id: t_a1b2c3
+//	    fields:
+//	      - name: Name
+//	        type: text
+//	      - name: Date of Birth
+//	        type: date
+//	        nullable: true
+//
+// Usage:
+//
+//	adalo-gen [generate] -schema adalo.yaml -out adalo_gen.go
+//
+// The command can be run with go generate:
+//
+//	//go:generate go run github.com/be-foo/adalo-sdk-go/cmd/adalo-gen -schema adalo.yaml -out adalo_gen.go
+//
+// To bootstrap a schema file, the infer subcommand scans the records of the passed collections
+// and infers the type of each field. The API key and app ID are read from the environment
+// variables ADALO_API_KEY and ADALO_APP_ID unless passed as flags:
+//
+//	adalo-gen infer -collection Person=t_a1b2c3 -collection Task=t_d4e5f6 -out adalo.yaml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "adalo-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run executes the subcommand selected by the command line arguments.
+func run(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "generate":
+			return runGenerate(args[1:])
+		case "infer":
+			return runInfer(args[1:])
+		}
+	}
+	return runGenerate(args)
+}
+
+// runGenerate generates the code as configured by the command line arguments.
+func runGenerate(args []string) error {
+	flags := flag.NewFlagSet("adalo-gen", flag.ContinueOnError)
+	schemaPath := flags.String("schema", "", "path to the YAML or JSON schema file (required)")
+	out := flags.String("out", "", "path to the generated Go file, prints to stdout if empty")
+	pkg := flags.String("package", "", "name of the generated package, defaults to the package in the schema or $GOPACKAGE")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *schemaPath == "" {
+		flags.Usage()
+		return fmt.Errorf("flag -schema is required")
+	}
+
+	schema, err := adalo.ReadSchema(*schemaPath)
+	if err != nil {
+		return err
+	}
+
+	packageName := *pkg
+	if packageName == "" {
+		packageName = schema.Package
+	}
+	if packageName == "" {
+		// set by go generate
+		packageName = os.Getenv("GOPACKAGE")
+	}
+	if packageName == "" {
+		return fmt.Errorf("package name is missing, set it in the schema or with -package")
+	}
+
+	source, err := generate(schema, packageName)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(*out, source)
+}
+
+// writeOutput writes data to the file at the given path, or to stdout if path is empty.
+func writeOutput(path string, data []byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
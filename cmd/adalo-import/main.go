@@ -0,0 +1,214 @@
+// Command adalo-import imports the rows of a CSV or newline-delimited JSON (NDJSON) file into an Adalo collection.
+//
+// Usage:
+//
+//	adalo-import -collection t_a1b2c3 [flags] persons.csv
+//
+// The API key and app ID are read from the environment variables ADALO_API_KEY and ADALO_APP_ID
+// unless passed as flags. The input is read from stdin if no file or - is passed.
+//
+// By default, every column is imported into the field of the same name. Columns are mapped to other
+// fields with -map Column=Field, optionally with the type of the field as -map Column=Field:type.
+// The types of all fields can be taken from a schema file as read by adalo-gen:
+//
+//	adalo-import -collection t_a1b2c3 -schema adalo.yaml -map "Birthday=Date of Birth" -key Email \
+//		-rejected rejected.csv -dry-run persons.csv
+//
+// With -key, rows are upserted by the key field instead of inserted. With -dry-run, the rows are
+// converted and validated and the action taken for each row is printed without writing anything.
+// Rejected rows are written to the -rejected file together with the reason, so they can be corrected
+// and imported again. The command exits with status 1 if any row was rejected.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/importer"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "adalo-import:", err)
+		os.Exit(1)
+	}
+}
+
+// mappingFlags collects the values of the repeatable -map flag in the format Column=Field[:type].
+type mappingFlags []importer.Mapping
+
+// String returns the flag values in the format they were passed.
+func (m *mappingFlags) String() string {
+	var values []string
+	for _, mapping := range *m {
+		value := mapping.Column + "=" + mapping.Field
+		if mapping.Type != "" {
+			value += ":" + string(mapping.Type)
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, ",")
+}
+
+// Set adds a mapping passed as Column=Field or Column=Field:type.
+func (m *mappingFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("mapping must be passed as Column=Field[:type], got %q", value)
+	}
+
+	mapping := importer.Mapping{Column: parts[0], Field: parts[1]}
+	if i := strings.LastIndex(mapping.Field, ":"); i > 0 && adalo.FieldType(mapping.Field[i+1:]).Valid() {
+		mapping.Field, mapping.Type = mapping.Field[:i], adalo.FieldType(mapping.Field[i+1:])
+	}
+	*m = append(*m, mapping)
+	return nil
+}
+
+// stringFlags collects the values of a repeatable flag.
+type stringFlags []string
+
+// String returns the flag values separated by commas.
+func (s *stringFlags) String() string {
+	return strings.Join(*s, ",")
+}
+
+// Set adds a value.
+func (s *stringFlags) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// run imports the input selected by the command line arguments and prints the report to stdout.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var mappings mappingFlags
+	var required stringFlags
+	flags := flag.NewFlagSet("adalo-import", flag.ContinueOnError)
+	collectionID := flags.String("collection", "", "ID of the collection to import into (required)")
+	apiKey := flags.String("api-key", os.Getenv("ADALO_API_KEY"), "Adalo API key, defaults to $ADALO_API_KEY")
+	appID := flags.String("app-id", os.Getenv("ADALO_APP_ID"), "Adalo app ID, defaults to $ADALO_APP_ID")
+	baseURL := flags.String("base-url", "", "overrides the base url of the collections API")
+	format := flags.String("format", "", "format of the input, csv or ndjson, defaults to the extension of the file or csv")
+	schemaPath := flags.String("schema", "", "path to a YAML or JSON schema file providing the field types")
+	flags.Var(&mappings, "map", "column to import as Column=Field[:type], can be repeated, imports all columns if not set")
+	flags.Var(&required, "required", "field that must not be empty, can be repeated")
+	key := flags.String("key", "", "field identifying existing records, which are updated instead of inserted")
+	separator := flags.String("separator", importer.DefaultSeparator, "separator of related IDs in relationship columns")
+	dryRun := flags.Bool("dry-run", false, "report what would be inserted and updated without writing")
+	rejectedPath := flags.String("rejected", "", "path to the file rejected rows are written to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *collectionID == "" {
+		flags.Usage()
+		return fmt.Errorf("flag -collection is required")
+	}
+	if *apiKey == "" || *appID == "" {
+		return fmt.Errorf("api key and app id are required, set $ADALO_API_KEY and $ADALO_APP_ID")
+	}
+	if flags.NArg() > 1 {
+		return fmt.Errorf("only one input file can be imported at a time")
+	}
+
+	opts := importer.Options{
+		Mappings:  mappings,
+		Required:  required,
+		KeyField:  *key,
+		Separator: *separator,
+		DryRun:    *dryRun,
+	}
+	if *schemaPath != "" {
+		schema, err := readCollectionSchema(*schemaPath, *collectionID)
+		if err != nil {
+			return err
+		}
+		opts.Schema = schema
+	}
+
+	path := flags.Arg(0)
+	if ext := strings.ToLower(filepath.Ext(path)); *format == "" && (ext == ".ndjson" || ext == ".jsonl") {
+		*format = "ndjson"
+	}
+	if *format != "" && *format != "csv" && *format != "ndjson" {
+		return fmt.Errorf("invalid format %q", *format)
+	}
+
+	input := stdin
+	if path != "" && path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	var reader importer.Reader = importer.NewCSVReader(input)
+	if *format == "ndjson" {
+		reader = importer.NewNDJSONReader(input)
+	}
+
+	if *rejectedPath != "" {
+		file, err := os.Create(*rejectedPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		opts.Rejected = importer.NewCSVRejectWriter(file)
+		if *format == "ndjson" {
+			opts.Rejected = importer.NewNDJSONRejectWriter(file)
+		}
+	}
+
+	opts.OnRow = func(outcome importer.Outcome) {
+		switch {
+		case outcome.Action == importer.ActionReject:
+			fmt.Fprintf(stdout, "line %d: reject: %s\n", outcome.Row.Line, outcome.Err)
+		case !*dryRun:
+		case outcome.ID != 0:
+			fmt.Fprintf(stdout, "line %d: %s %d\n", outcome.Row.Line, outcome.Action, outcome.ID)
+		default:
+			fmt.Fprintf(stdout, "line %d: %s\n", outcome.Row.Line, outcome.Action)
+		}
+	}
+
+	var clientOpts []adalo.Option
+	if *baseURL != "" {
+		clientOpts = append(clientOpts, adalo.WithCollectionsBaseURL(*baseURL))
+	}
+	client := adalo.NewClient(*apiKey, *appID, clientOpts...)
+
+	result, err := importer.Import(ctx, client.Collection(*collectionID), reader, opts)
+	if *dryRun {
+		fmt.Fprintf(stdout, "dry run: would insert %d, update %d, reject %d rows\n", result.Inserted, result.Updated, result.Rejected)
+	} else {
+		fmt.Fprintf(stdout, "inserted %d, updated %d, rejected %d rows\n", result.Inserted, result.Updated, result.Rejected)
+	}
+	if err != nil {
+		return err
+	}
+	if result.Rejected > 0 {
+		return fmt.Errorf("%d rows rejected", result.Rejected)
+	}
+	return nil
+}
+
+// readCollectionSchema reads the schema file at the given path with adalo.ReadSchema
+// and returns the collection with the given ID.
+func readCollectionSchema(path, collectionID string) (*adalo.CollectionSchema, error) {
+	schema, err := adalo.ReadSchema(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range schema.Collections {
+		if schema.Collections[i].ID == collectionID {
+			return &schema.Collections[i], nil
+		}
+	}
+	return nil, fmt.Errorf("collection %s not found in schema %s", collectionID, path)
+}
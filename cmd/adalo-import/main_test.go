@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// john is the record of the person collection in the tests.
+var john = map[string]interface{}{"Email": "john@example.com", "Name": "John", "Age": 21}
+
+// args returns the arguments to import into the person collection of srv followed by extra.
+func args(srv *adalotest.Server, extra ...string) []string {
+	return append([]string{"-api-key", "key", "-app-id", "app", "-base-url", srv.URL, "-collection", "t_person",
+		"-schema", "testdata/schema.yaml"}, extra...)
+}
+
+func TestRun(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("t_person", john)
+	defer srv.Close()
+
+	rejected := filepath.Join(t.TempDir(), "rejected.csv")
+	var stdout bytes.Buffer
+	err := run(context.Background(), args(srv, "-map", "Email=Email", "-map", "Name=Name", "-map", "Age=Age",
+		"-map", "Birthday=Date of Birth", "-key", "Email", "-rejected", rejected, "testdata/persons.csv"), nil, &stdout)
+	assert.EqualError(t, err, "1 rows rejected")
+	assert.Equal(t, "line 4: reject: column Age: invalid number \"old\"\ninserted 1, updated 1, rejected 1 rows\n", stdout.String())
+
+	john, _ := srv.Record("t_person", 1)
+	assert.Equal(t, json.Number("22"), john["Age"])
+	assert.Equal(t, "1999-03-04", john["Date of Birth"])
+	assert.Len(t, srv.Records("t_person"), 2)
+
+	data, err := os.ReadFile(rejected)
+	assert.Nil(t, err)
+	assert.Equal(t, "Email,Name,Age,Birthday,import_error\nrichard@example.com,Richard,old,1990-01-01,\"column Age: invalid number \"\"old\"\"\"\n", string(data))
+}
+
+func TestRun_dryRun(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("t_person", john)
+	defer srv.Close()
+
+	input := `{"Email": "john@example.com", "Age": 23}` + "\n" + `{"Email": "max@example.com", "Age": 40}` + "\n"
+	var stdout bytes.Buffer
+	err := run(context.Background(), args(srv, "-format", "ndjson", "-key", "Email", "-dry-run"), strings.NewReader(input), &stdout)
+	assert.Nil(t, err)
+	assert.Equal(t, "line 1: update 1\nline 2: insert\ndry run: would insert 1, update 1, reject 0 rows\n", stdout.String())
+
+	john, _ := srv.Record("t_person", 1)
+	assert.Equal(t, json.Number("21"), john["Age"])
+	assert.Len(t, srv.Records("t_person"), 1)
+}
+
+func TestRun_errors(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("t_person", john)
+	defer srv.Close()
+
+	tests := map[string][]string{
+		"missing collection": {"-api-key", "key", "-app-id", "app"},
+		"missing api key":    {"-api-key", "", "-app-id", "app", "-collection", "t_person"},
+		"invalid mapping":    args(srv, "-map", "Email"),
+		"invalid format":     args(srv, "-format", "xlsx"),
+		"unknown collection": {"-api-key", "key", "-app-id", "app", "-collection", "t_task", "-schema", "testdata/schema.yaml"},
+		"missing file":       args(srv, "testdata/missing.csv"),
+		"several files":      args(srv, "a.csv", "b.csv"),
+	}
+	for name, arguments := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := run(context.Background(), arguments, strings.NewReader(""), &bytes.Buffer{})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMappingFlags(t *testing.T) {
+	var mappings mappingFlags
+	assert.Nil(t, mappings.Set("Birthday=Date of Birth:date"))
+	assert.Nil(t, mappings.Set("Note=Remark: Text"))
+	assert.Nil(t, mappings.Set("Email=Email"))
+	assert.Error(t, mappings.Set("=Email"))
+
+	assert.Equal(t, "Birthday=Date of Birth:date,Note=Remark: Text,Email=Email", mappings.String())
+}
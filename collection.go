@@ -1,206 +1,173 @@
 package adalo
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
-	"io/ioutil"
+	"github.com/be-foo/adalo-sdk-go/types"
 	"net/http"
-	"strings"
+	"reflect"
+	"time"
 )
 
 // Collection provides a CRUD interface to an Adalo collection.
 type Collection struct {
 	// ID of collection in Adalo
 	ID string
+
+	// client performs the requests, the default client is used when nil
+	client *Client
+
+	// filter is applied when listing records, see Where
+	filter *Filter
+
+	// includes lists the related records loaded with each record, see Include
+	includes []include
+
+	// cacheTTL is the time responses are cached, nothing is cached when zero, see Cached
+	cacheTTL time.Duration
 }
 
-// NewCollection initializes a Collection.
+// NewCollection initializes a Collection that uses the global ApiKey and AppID.
 func NewCollection(collectionID string) *Collection {
-	return &Collection{ID: collectionID}
+	return defaultClient.Collection(collectionID)
+}
+
+// apiClient returns the client that performs the requests of this collection.
+func (c *Collection) apiClient() *Client {
+	if c.client == nil {
+		return defaultClient
+	}
+	return c.client
 }
 
 // collectionAPIBaseURL returns the base url for api calls.
 func (c *Collection) collectionAPIBaseURL() string {
-	return fmt.Sprintf("http://api.adalo.com/apps/%s/collections/%s", AppID, c.ID)
+	cl := c.apiClient()
+	return fmt.Sprintf("%s/apps/%s/collections/%s", cl.collectionsBaseURL, cl.AppID(), c.ID)
 }
 
-// All gets all items in collection and binds result to the passed result variable.
-func (c *Collection) All(result interface{}) error {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", c.collectionAPIBaseURL(), nil)
-	if err != nil {
-		return err
-	}
+// recordURL returns the url for api calls on the record with the given id.
+func (c *Collection) recordURL(id int) string {
+	return fmt.Sprintf("%s/%d", c.collectionAPIBaseURL(), id)
+}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ApiKey))
-	req.Header.Add("Content-Type", "application/json")
+// All gets all items in collection and binds result to the passed result variable, which should point to a slice.
+// The records are fetched page by page until the collection is exhausted.
+func (c *Collection) All(result interface{}) error {
+	return c.AllContext(context.Background(), result)
+}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return err
+// AllContext is like All but the requests are cancelled as soon as ctx is done.
+func (c *Collection) AllContext(ctx context.Context, result interface{}) error {
+	all := &Page{}
+	it := c.Iterate(ctx, ListOptions{})
+	for it.Next() {
+		all.Records = append(all.Records, it.Record())
+		all.relationIDs = append(all.relationIDs, it.relationIDs())
 	}
-	defer res.Body.Close()
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
+	if err := it.Err(); err != nil {
 		return err
 	}
-
-	// check if an explicit error message was returned
-	var errorResponse apiErrorResponse
-	_ = json.Unmarshal(body, &errorResponse)
-	if errorResponse != (apiErrorResponse{}) {
-		return errors.New(strings.ToLower(errorResponse.Error))
-	}
-
-	return json.Unmarshal(body, &result)
+	return all.Bind(result)
 }
 
 // Get fetches a record from the collection by its id and binds it to passed result variable.
 func (c *Collection) Get(id int, result interface{}) error {
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/%d", c.collectionAPIBaseURL(), id), nil)
+	return c.GetContext(context.Background(), id, result)
+}
+
+// GetContext is like Get but the request is cancelled as soon as ctx is done.
+func (c *Collection) GetContext(ctx context.Context, id int, result interface{}) error {
+	body, err := c.get(ctx, c.recordCacheKey(id), c.recordURL(id))
 	if err != nil {
 		return err
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ApiKey))
-	req.Header.Add("Content-Type", "application/json")
+	if len(c.includes) == 0 {
+		return decodeResponse(body, result)
+	}
 
-	res, err := client.Do(req)
+	records := []json.RawMessage{body}
+	dangling, err := c.loadIncludes(ctx, records)
 	if err != nil {
 		return err
 	}
-	defer res.Body.Close()
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
+	if err := decodeResponse(records[0], result); err != nil {
 		return err
 	}
-
-	// check if an explicit error message was returned
-	var errorResponse apiErrorResponse
-	_ = json.Unmarshal(body, &errorResponse)
-	if errorResponse != (apiErrorResponse{}) {
-		return errors.New(strings.ToLower(errorResponse.Error))
+	if dangling != nil {
+		dangling[0].bind(reflect.ValueOf(result))
 	}
-
-	return json.Unmarshal(body, &result)
+	return nil
 }
 
 // Insert will insert a new record to the collection and bind created item to passed result variable.
 func (c *Collection) Insert(input interface{}, result interface{}) error {
-	inputBytes, err := json.Marshal(input)
-	if err != nil {
-		return err
-	}
-	payload := bytes.NewReader(inputBytes)
-
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", c.collectionAPIBaseURL(), payload)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ApiKey))
-	req.Header.Add("Content-Type", "application/json")
-
-	res, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
+	return c.InsertContext(context.Background(), input, result)
+}
 
-	body, err := ioutil.ReadAll(res.Body)
+// InsertContext is like Insert but the request is cancelled as soon as ctx is done.
+func (c *Collection) InsertContext(ctx context.Context, input interface{}, result interface{}) error {
+	_, body, err := c.apiClient().do(ctx, http.MethodPost, c.collectionAPIBaseURL(), input)
 	if err != nil {
+		c.invalidate(0, nil)
 		return err
 	}
-
-	// check if an explicit error message was returned
-	var errorResponse apiErrorResponse
-	_ = json.Unmarshal(body, &errorResponse)
-	if errorResponse != (apiErrorResponse{}) {
-		return errors.New(strings.ToLower(errorResponse.Error))
-	}
-
-	return json.Unmarshal(body, &result)
+	id, _ := types.RecordID(body)
+	c.invalidate(id, body)
+	return decodeResponse(body, result)
 }
 
 // Update will update the record with given id in the Adalo collection and bind updated item to passed result variable.
 func (c *Collection) Update(id int, input interface{}, result interface{}) error {
-	inputBytes, err := json.Marshal(input)
-	if err != nil {
-		return err
-	}
-	payload := bytes.NewReader(inputBytes)
-
-	client := &http.Client{}
-	req, err := http.NewRequest("PUT", fmt.Sprintf("%s/%d", c.collectionAPIBaseURL(), id), payload)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ApiKey))
-	req.Header.Add("Content-Type", "application/json")
-
-	res, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
+	return c.UpdateContext(context.Background(), id, input, result)
+}
 
-	body, err := ioutil.ReadAll(res.Body)
+// UpdateContext is like Update but the request is cancelled as soon as ctx is done.
+func (c *Collection) UpdateContext(ctx context.Context, id int, input interface{}, result interface{}) error {
+	_, body, err := c.apiClient().do(ctx, http.MethodPut, c.recordURL(id), input)
 	if err != nil {
+		c.invalidate(id, nil)
 		return err
 	}
-
-	// check if an explicit error message was returned
-	var errorResponse apiErrorResponse
-	_ = json.Unmarshal(body, &errorResponse)
-	if errorResponse != (apiErrorResponse{}) {
-		return errors.New(strings.ToLower(errorResponse.Error))
-	}
-
-	return json.Unmarshal(body, &result)
+	c.invalidate(id, body)
+	return decodeResponse(body, result)
 }
 
 // Delete removes a record from the Adalo collection.
 func (c *Collection) Delete(id int) error {
-	client := &http.Client{}
-	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/%d", c.collectionAPIBaseURL(), id), nil)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ApiKey))
-	req.Header.Add("Content-Type", "application/json")
-
-	res, err := client.Do(req)
-	if err != nil {
-		return err
-	}
-	defer res.Body.Close()
+	return c.DeleteContext(context.Background(), id)
+}
 
-	body, err := ioutil.ReadAll(res.Body)
+// DeleteContext is like Delete but the request is cancelled as soon as ctx is done.
+func (c *Collection) DeleteContext(ctx context.Context, id int) error {
+	url := c.recordURL(id)
+	res, body, err := c.apiClient().do(ctx, http.MethodDelete, url, nil)
+	c.invalidate(id, nil)
 	if err != nil {
 		return err
 	}
 
-	// check if an explicit error message was returned
-	var errorResponse apiErrorResponse
-	_ = json.Unmarshal(body, &errorResponse)
-	if errorResponse != (apiErrorResponse{}) {
-		return errors.New(strings.ToLower(errorResponse.Error))
-	}
-
 	switch res.StatusCode {
 	case 204:
-		return ErrorResourceNotFound // BUG: Adalo will return with 204 even with successful requests
+		// BUG: Adalo will return with 204 even with successful requests
+		return &APIError{
+			StatusCode: res.StatusCode,
+			Message:    ErrorResourceNotFound.Error(),
+			Method:     http.MethodDelete,
+			URL:        url,
+			Header:     res.Header,
+			Body:       body,
+		}
 	case 201:
 		return nil
 	default:
 		return nil
 	}
 }
+
+// decodeResponse binds the response body to the passed result variable.
+func decodeResponse(body []byte, result interface{}) error {
+	return json.Unmarshal(body, &result)
+}
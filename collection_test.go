@@ -1,18 +1,18 @@
 package adalo
 
 import (
+	"context"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
 	"github.com/stretchr/testify/assert"
-	"os"
 	"testing"
 )
 
 // person represents a record in the Persons collection in Adalo
 type person struct {
-	ID        int    `json:"id"`
-	Name      string `json:"Name"`
-	Age       int    `json:"Age"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Record
+	Name string `json:"Name"`
+	Age  int    `json:"Age"`
 }
 
 // personInput represents the schema for inputting a person in the Adalo collection
@@ -22,19 +22,12 @@ type personInput struct {
 }
 
 // collection is the interface for the collection we will use in this test
-var collection *Collection
+var collection = NewCollection(testCollectionID)
 
 // invalidID is just a random high number that we use to test for non-existent IDs.
-// We must ensure that a record with this ID will never exist in the Adalo app this project is tested with.
+// The tests never create as many records in the testServer.
 const invalidID = 8834
 
-func init() {
-	if os.Getenv("TEST_COLLECTION_ID") == "" {
-		panic("environment variable TEST_COLLECTION_ID is not set")
-	}
-	collection = NewCollection(os.Getenv("TEST_COLLECTION_ID"))
-}
-
 func TestCollection_All(t *testing.T) {
 	t.Run("valid request", func(t *testing.T) {
 		setup()
@@ -47,14 +40,14 @@ func TestCollection_All(t *testing.T) {
 		setup(unauthorized)
 		var res []interface{}
 		err := collection.All(res)
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 
 	t.Run("app mismatch", func(t *testing.T) {
 		setup(invalidApp)
 		var res []interface{}
 		err := collection.All(res)
-		assert.Equal(t, ErrorAppMismatch, err)
+		assert.True(t, errors.Is(err, ErrorAppMismatch))
 	})
 }
 
@@ -86,7 +79,7 @@ func TestCollection_Insert(t *testing.T) {
 			Name: "John",
 			Age:  21,
 		}, nil)
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 
 	t.Run("app mismatch", func(t *testing.T) {
@@ -95,7 +88,7 @@ func TestCollection_Insert(t *testing.T) {
 			Name: "John",
 			Age:  21,
 		}, nil)
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 }
 
@@ -128,13 +121,13 @@ func TestCollection_Get(t *testing.T) {
 	t.Run("unauthorized", func(t *testing.T) {
 		setup(unauthorized)
 		err := collection.Get(1, nil)
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 
 	t.Run("app mismatch", func(t *testing.T) {
 		setup(invalidApp)
 		err := collection.Get(1, nil)
-		assert.Equal(t, ErrorAppMismatch, err)
+		assert.True(t, errors.Is(err, ErrorAppMismatch))
 	})
 }
 
@@ -178,7 +171,7 @@ func TestCollection_Update(t *testing.T) {
 			Name: "Richard Johnson",
 			Age:  89,
 		}, nil)
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 
 	t.Run("app mismatch", func(t *testing.T) {
@@ -187,7 +180,7 @@ func TestCollection_Update(t *testing.T) {
 			Name: "Richard Johnson",
 			Age:  89,
 		}, nil)
-		assert.Equal(t, ErrorAppMismatch, err)
+		assert.True(t, errors.Is(err, ErrorAppMismatch))
 	})
 }
 
@@ -215,18 +208,32 @@ func TestCollection_Delete(t *testing.T) {
 	t.Run("with id that does not exist", func(t *testing.T) {
 		setup()
 		err := collection.Delete(invalidID)
-		assert.Equal(t, ErrorResourceNotFound, err)
+		assert.True(t, errors.Is(err, ErrorResourceNotFound))
+	})
+
+	t.Run("with no content response", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app")
+		defer srv.Close()
+		srv.RespondNoContentToDeletes()
+		ids := srv.Seed("persons", personInput{Name: "Mr. Oldman", Age: 321})
+
+		// Adalo responds with 204 to some successful deletes, which cannot be told apart from missing records
+		persons := newTestClient(srv).Collection("persons")
+		err := persons.DeleteContext(context.Background(), ids[0])
+		assert.True(t, errors.Is(err, ErrorResourceNotFound))
+		_, ok := srv.Record("persons", ids[0])
+		assert.False(t, ok)
 	})
 
 	t.Run("unauthorized", func(t *testing.T) {
 		setup(unauthorized)
 		err := collection.Delete(1)
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 
 	t.Run("app mismatch", func(t *testing.T) {
 		setup(invalidApp)
 		err := collection.Delete(1)
-		assert.Equal(t, ErrorAppMismatch, err)
+		assert.True(t, errors.Is(err, ErrorAppMismatch))
 	})
 }
@@ -0,0 +1,117 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultConflictAttempts is the number of attempts made by Modify before giving up on conflicting updates.
+const DefaultConflictAttempts = 3
+
+// ErrorConflict is returned by UpdateIfUnchanged wrapped in a *ConflictError when the record was updated
+// since it was read.
+var ErrorConflict = errors.New("record was updated concurrently")
+
+// ConflictError is returned by UpdateIfUnchanged when the updated_at of the record does not match the expected time.
+// It can be compared to ErrorConflict with errors.Is.
+type ConflictError struct {
+	// ID of the record
+	ID int
+
+	// Expected is the updated_at the record was expected to have
+	Expected time.Time
+
+	// Actual is the updated_at of the record
+	Actual time.Time
+}
+
+// Error returns a description of the conflict.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: record %d was updated at %s, expected %s",
+		ErrorConflict, e.ID, e.Actual.Format(time.RFC3339Nano), e.Expected.Format(time.RFC3339Nano))
+}
+
+// Is reports whether target is ErrorConflict.
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrorConflict
+}
+
+// UpdateIfUnchanged updates the record with the given id only if its updated_at still equals expectedUpdatedAt,
+// e.g. the UpdatedAt of the Record that was read before. Otherwise, it returns a *ConflictError without updating.
+//
+// The Adalo API does not support conditional updates, so the record is re-read right before the update.
+// This narrows the window for lost updates considerably, but cannot close it completely.
+func (c *Collection) UpdateIfUnchanged(ctx context.Context, id int, expectedUpdatedAt time.Time, input interface{}, result interface{}) error {
+	var current Record
+	if err := c.uncached().GetContext(ctx, id, &current); err != nil {
+		return err
+	}
+	if !current.UpdatedAt.Equal(expectedUpdatedAt) {
+		return &ConflictError{ID: id, Expected: expectedUpdatedAt, Actual: current.UpdatedAt}
+	}
+	return c.UpdateContext(ctx, id, input, result)
+}
+
+// Modify reads the record with the given id, passes it to mutate and updates the record with the returned input
+// using UpdateIfUnchanged. On conflicts, the record is read again and mutate is re-applied, up to
+// DefaultConflictAttempts times. mutate must not have side effects, since it may be called several times.
+//
+//	err := counters.Modify(ctx, id, func(current adalo.Document) (interface{}, error) {
+//		return map[string]int{"Count": current.Int("Count") + 1}, nil
+//	}, nil)
+func (c *Collection) Modify(ctx context.Context, id int, mutate func(current Document) (interface{}, error), result interface{}) error {
+	return c.modify(ctx, id, func(record json.RawMessage) (interface{}, error) {
+		var current Document
+		if err := decodeResponse(record, &current); err != nil {
+			return nil, err
+		}
+		return mutate(current)
+	}, result)
+}
+
+// modify implements Modify for mutations of the raw record.
+func (c *Collection) modify(ctx context.Context, id int, mutate func(record json.RawMessage) (interface{}, error), result interface{}) error {
+	return RetryOnConflict(ctx, DefaultConflictAttempts, func() error {
+		var record json.RawMessage
+		if err := c.uncached().GetContext(ctx, id, &record); err != nil {
+			return err
+		}
+		var current Record
+		if err := decodeResponse(record, &current); err != nil {
+			return err
+		}
+
+		input, err := mutate(record)
+		if err != nil {
+			return err
+		}
+		return c.UpdateIfUnchanged(ctx, id, current.UpdatedAt, input, result)
+	})
+}
+
+// RetryOnConflict calls fn until it returns an error other than ErrorConflict, at most attempts times.
+// It returns the error of the last call, or the error of ctx if it is done before the next attempt.
+func RetryOnConflict(ctx context.Context, attempts int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < attempts || attempt == 0; attempt++ {
+		if attempt > 0 {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+		}
+		if err = fn(); !errors.Is(err, ErrorConflict) {
+			return err
+		}
+	}
+	return err
+}
+
+// uncached returns a copy of the collection that reads records directly from the API without related records.
+func (c *Collection) uncached() *Collection {
+	uncached := c.WithoutIncludes()
+	uncached.cacheTTL = 0
+	return uncached
+}
@@ -0,0 +1,151 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// counter is a record of the counters collection
+type counter struct {
+	Record
+	Count int `json:"Count"`
+}
+
+func TestCollection_UpdateIfUnchanged(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("counters", map[string]interface{}{"Count": 0})
+	defer srv.Close()
+	counters := newTestClient(srv).Collection("counters").Cached(time.Minute)
+	ctx := context.Background()
+
+	var read counter
+	assert.Nil(t, counters.GetContext(ctx, 1, &read))
+
+	var updated counter
+	err := counters.UpdateIfUnchanged(ctx, 1, read.UpdatedAt, map[string]int{"Count": 1}, &updated)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, updated.Count)
+	assert.True(t, updated.UpdatedAt.After(read.UpdatedAt))
+
+	// the record was updated since it was read, although the cached record is outdated
+	err = counters.UpdateIfUnchanged(ctx, 1, read.UpdatedAt, map[string]int{"Count": 5}, nil)
+	assert.True(t, errors.Is(err, ErrorConflict))
+	var conflict *ConflictError
+	if assert.True(t, errors.As(err, &conflict)) {
+		assert.Equal(t, 1, conflict.ID)
+		assert.Equal(t, read.UpdatedAt, conflict.Expected)
+		assert.Equal(t, updated.UpdatedAt, conflict.Actual)
+	}
+	stored, _ := srv.Record("counters", 1)
+	assert.Equal(t, json.Number("1"), stored["Count"])
+
+	err = counters.UpdateIfUnchanged(ctx, invalidID, read.UpdatedAt, map[string]int{"Count": 5}, nil)
+	assert.True(t, errors.Is(err, ErrorResourceNotFound))
+}
+
+func TestCollection_Modify(t *testing.T) {
+	t.Run("retries on conflict", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("counters", map[string]interface{}{"Count": 0})
+		defer srv.Close()
+		counters := newTestClient(srv).Collection("counters").Cached(time.Minute)
+		ctx := context.Background()
+
+		calls := 0
+		var result counter
+		err := counters.Modify(ctx, 1, func(current Document) (interface{}, error) {
+			calls++
+			if calls == 1 {
+				// another writer increments the counter in the meantime
+				assert.Nil(t, counters.Update(1, map[string]int{"Count": current.Int("Count") + 1}, nil))
+			}
+			return map[string]int{"Count": current.Int("Count") + 1}, nil
+		}, &result)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, result.Count)
+	})
+
+	t.Run("gives up", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("counters", map[string]interface{}{"Count": 0})
+		defer srv.Close()
+		counters := newTestClient(srv).Collection("counters").Cached(time.Minute)
+
+		calls := 0
+		err := counters.Modify(context.Background(), 1, func(current Document) (interface{}, error) {
+			calls++
+			assert.Nil(t, counters.Update(1, map[string]int{"Count": calls}, nil))
+			return map[string]int{"Count": 0}, nil
+		}, nil)
+
+		assert.True(t, errors.Is(err, ErrorConflict))
+		assert.Equal(t, DefaultConflictAttempts, calls)
+	})
+
+	t.Run("typed", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("counters", map[string]interface{}{"Count": 0})
+		defer srv.Close()
+		counters := newTestClient(srv).Collection("counters").Cached(time.Minute)
+
+		typed := Typed[counter, map[string]int](counters)
+		result, err := typed.Modify(context.Background(), 1, func(current counter) (map[string]int, error) {
+			return map[string]int{"Count": current.Count + 10}, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 10, result.Count)
+
+		_, err = typed.UpdateIfUnchanged(context.Background(), 1, time.Time{}, map[string]int{"Count": 0})
+		assert.True(t, errors.Is(err, ErrorConflict))
+	})
+
+	t.Run("mutate error", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("counters", map[string]interface{}{"Count": 0})
+		defer srv.Close()
+		counters := newTestClient(srv).Collection("counters").Cached(time.Minute)
+
+		failed := errors.New("failed")
+		err := counters.Modify(context.Background(), 1, func(current Document) (interface{}, error) {
+			return nil, failed
+		}, nil)
+		assert.Equal(t, failed, err)
+		assert.Equal(t, 0, srv.CountRequests(http.MethodPut, ""))
+	})
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	conflict := &ConflictError{ID: 1}
+
+	calls := 0
+	err := RetryOnConflict(context.Background(), 3, func() error {
+		calls++
+		return conflict
+	})
+	assert.Equal(t, conflict, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = RetryOnConflict(context.Background(), 3, func() error {
+		calls++
+		if calls < 2 {
+			return conflict
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	calls = 0
+	err = RetryOnConflict(ctx, 3, func() error {
+		calls++
+		cancel()
+		return conflict
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,155 @@
+package adalo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go/types"
+	"strconv"
+	"time"
+)
+
+// Document is a record of any collection, decoded to a map from field names to values.
+// Use it instead of a struct when the fields are not known at compile time, e.g. as the result of
+// Collection.Get or Collection.All. Numbers are decoded as json.Number, so IDs and large numbers stay exact.
+//
+// The accessors convert the value of a field to the requested type and return the zero value if the
+// field is missing, empty or cannot be converted.
+type Document map[string]interface{}
+
+// UnmarshalJSON decodes a JSON object into the document, keeping numbers as json.Number.
+func (d *Document) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*d = nil
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return err
+	}
+	*d = fields
+	return nil
+}
+
+// ID returns the id of the record.
+func (d Document) ID() int {
+	return d.Int("id")
+}
+
+// RecordID returns the id of the record, so documents can be passed to UpdateRecord and DeleteRecord.
+func (d Document) RecordID() int {
+	return d.ID()
+}
+
+// Has reports whether the document contains the field.
+func (d Document) Has(field string) bool {
+	_, ok := d[field]
+	return ok
+}
+
+// Get returns the raw value of the field.
+func (d Document) Get(field string) interface{} {
+	return d[field]
+}
+
+// Set sets the value of the field, initializing the document if necessary.
+func (d *Document) Set(field string, value interface{}) {
+	if *d == nil {
+		*d = Document{}
+	}
+	(*d)[field] = value
+}
+
+// String returns the value of a text field. Numbers and booleans are formatted as text.
+func (d Document) String(field string) string {
+	switch v := d[field].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Float returns the value of a number field. Numbers formatted as text are parsed.
+func (d Document) Float(field string) float64 {
+	switch v := d[field].(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case float64:
+		return v
+	case float32:
+		return float64(v)
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		f, _ := strconv.ParseFloat(v, 64)
+		return f
+	}
+	return 0
+}
+
+// Int returns the value of a number field truncated to an int.
+func (d Document) Int(field string) int {
+	switch v := d[field].(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	return int(d.Float(field))
+}
+
+// Bool returns the value of a true/false field. Booleans formatted as text are parsed.
+func (d Document) Bool(field string) bool {
+	switch v := d[field].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+// Time returns the value of a date or date & time field, e.g. created_at.
+func (d Document) Time(field string) time.Time {
+	switch v := d[field].(type) {
+	case time.Time:
+		return v
+	case string:
+		var dt types.DateTime
+		if err := dt.UnmarshalJSON([]byte(strconv.Quote(v))); err == nil {
+			return dt.Time
+		}
+	}
+	return time.Time{}
+}
+
+// Relation returns the value of a relationship field, including the related records if they were loaded with Include.
+func (d Document) Relation(field string) Relation {
+	var relation Relation
+	data, err := json.Marshal(d[field])
+	if err != nil {
+		return relation
+	}
+	_ = relation.UnmarshalJSON(data)
+	return relation
+}
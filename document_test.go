@@ -0,0 +1,103 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDocument_UnmarshalJSON(t *testing.T) {
+	var doc Document
+	err := json.Unmarshal([]byte(`{
+		"id": 9007199254740993,
+		"Name": "John",
+		"Age": 21.5,
+		"Score": "42",
+		"Admin": true,
+		"Verified": "true",
+		"Date of Birth": "1999-12-31",
+		"created_at": "2021-03-04T10:15:30.250Z",
+		"Tasks": [1, 2],
+		"Mentor": {"id": 3, "Name": "Jane"},
+		"Nickname": null
+	}`), &doc)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 9007199254740993, doc.ID())
+	assert.Equal(t, doc.ID(), doc.RecordID())
+	assert.Equal(t, "John", doc.String("Name"))
+	assert.Equal(t, "21.5", doc.String("Age"))
+	assert.Equal(t, 21.5, doc.Float("Age"))
+	assert.Equal(t, 21, doc.Int("Age"))
+	assert.Equal(t, 42, doc.Int("Score"))
+	assert.True(t, doc.Bool("Admin"))
+	assert.True(t, doc.Bool("Verified"))
+	assert.Equal(t, time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC), doc.Time("Date of Birth"))
+	assert.True(t, doc.Time("created_at").Equal(time.Date(2021, time.March, 4, 10, 15, 30, 250000000, time.UTC)))
+	assert.Equal(t, []int{1, 2}, doc.Relation("Tasks").IDs)
+	assert.Equal(t, []int{3}, doc.Relation("Mentor").IDs)
+	assert.True(t, doc.Relation("Mentor").Loaded())
+
+	assert.True(t, doc.Has("Nickname"))
+	assert.Nil(t, doc.Get("Nickname"))
+	assert.Equal(t, "", doc.String("Nickname"))
+	assert.False(t, doc.Has("Missing"))
+	assert.Equal(t, 0, doc.Int("Missing"))
+	assert.False(t, doc.Bool("Name"))
+	assert.True(t, doc.Time("Name").IsZero())
+	assert.Empty(t, doc.Relation("Missing").IDs)
+}
+
+func TestDocument_MarshalJSON(t *testing.T) {
+	var doc Document
+	assert.Nil(t, json.Unmarshal([]byte(`{"id": 9007199254740993, "Name": "John"}`), &doc))
+
+	doc.Set("Age", 22)
+	doc.Set("Tasks", Relation{IDs: []int{4}})
+	data, err := json.Marshal(doc)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id": 9007199254740993, "Name": "John", "Age": 22, "Tasks": [4]}`, string(data))
+
+	var empty Document
+	empty.Set("Name", "Jane")
+	assert.Equal(t, "Jane", empty.String("Name"))
+}
+
+func TestDocument_collection(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("persons", personInput{Name: "John", Age: 21}, personInput{Name: "Jane", Age: 28})
+
+	persons := newTestClient(srv).Collection("persons")
+	ctx := context.Background()
+
+	var docs []Document
+	assert.Nil(t, persons.AllContext(ctx, &docs))
+	if assert.Len(t, docs, 2) {
+		assert.Equal(t, 1, docs[0].ID())
+		assert.Equal(t, "Jane", docs[1].String("Name"))
+	}
+
+	var doc Document
+	assert.Nil(t, persons.GetContext(ctx, 2, &doc))
+	assert.Equal(t, 2, doc.ID())
+
+	var updated Document
+	assert.Nil(t, persons.UpdateRecord(ctx, doc, Document{"Age": doc.Int("Age") + 1}, &updated))
+	assert.Equal(t, "Jane", updated.String("Name"))
+	assert.Equal(t, 29, updated.Int("Age"))
+
+	it := persons.Iterate(ctx, ListOptions{})
+	assert.True(t, it.Next())
+	assert.Nil(t, it.Scan(&doc))
+	assert.Equal(t, 1, doc.ID())
+
+	typed := Typed[Document, Document](persons)
+	inserted, err := typed.Insert(ctx, Document{"Name": "Jane"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, inserted.ID())
+	assert.Nil(t, typed.DeleteRecord(ctx, inserted))
+}
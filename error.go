@@ -0,0 +1,118 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when the Adalo API responds with an error.
+// It can be compared to the sentinel errors of this package with errors.Is.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+
+	// Message is the error message returned by the API, or the status text
+	// if the response did not contain an explicit error message
+	Message string
+
+	// Method is the HTTP method of the failed request
+	Method string
+
+	// URL is the url of the failed request
+	URL string
+
+	// Header contains the headers of the response
+	Header http.Header
+
+	// Body is the raw body of the response
+	Body []byte
+}
+
+// Error returns the lower-cased error message, which matches the message of the according sentinel error.
+func (e *APIError) Error() string {
+	return strings.ToLower(e.Message)
+}
+
+// Is reports whether the error corresponds to the passed sentinel error,
+// e.g. errors.Is(err, ErrorResourceNotFound).
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrorUnauthorized:
+		if e.StatusCode == http.StatusUnauthorized {
+			return true
+		}
+	case ErrorResourceNotFound:
+		if e.StatusCode == http.StatusNotFound {
+			return true
+		}
+	case ErrorAppMismatch, ErrorUserNotFound:
+	default:
+		return false
+	}
+	return e.Error() == target.Error()
+}
+
+// newAPIError checks the response for an error and returns it as *APIError.
+// It returns nil if the request was successful.
+func newAPIError(req *http.Request, res *http.Response, body []byte) error {
+	// check if an explicit error message was returned
+	var errorResponse apiErrorResponse
+	_ = json.Unmarshal(body, &errorResponse)
+	if errorResponse == (apiErrorResponse{}) && res.StatusCode < 400 {
+		return nil
+	}
+
+	message := errorResponse.Error
+	if message == "" {
+		message = http.StatusText(res.StatusCode)
+	}
+
+	return &APIError{
+		StatusCode: res.StatusCode,
+		Message:    message,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		Header:     res.Header,
+		Body:       body,
+	}
+}
+
+// IsRetryable reports whether the request that caused err may succeed when it is sent again,
+// e.g. because the API was rate limited, temporarily unavailable or the request timed out.
+func IsRetryable(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusRequestTimeout,
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsAuthError reports whether err was caused by invalid credentials,
+// i.e. an invalid ApiKey or an AppID that does not match the ApiKey.
+func IsAuthError(err error) bool {
+	if errors.Is(err, ErrorUnauthorized) || errors.Is(err, ErrorAppMismatch) {
+		return true
+	}
+
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,90 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	t.Run("carries response metadata", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", "abc")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": "Unauthorized"}`))
+		}))
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL))
+		err := client.Collection("persons").Get(1, nil)
+
+		var apiErr *APIError
+		if assert.True(t, errors.As(err, &apiErr)) {
+			assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+			assert.Equal(t, "Unauthorized", apiErr.Message)
+			assert.Equal(t, http.MethodGet, apiErr.Method)
+			assert.Equal(t, srv.URL+"/apps/app/collections/persons/1", apiErr.URL)
+			assert.Equal(t, "abc", apiErr.Header.Get("X-Request-Id"))
+			assert.Equal(t, `{"error": "Unauthorized"}`, string(apiErr.Body))
+		}
+		assert.Equal(t, "unauthorized", err.Error())
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
+		assert.True(t, IsAuthError(err))
+		assert.False(t, IsRetryable(err))
+	})
+
+	t.Run("without explicit error message", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`<html>maintenance</html>`))
+		}))
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithRetryPolicy(NoRetries))
+		err := client.Collection("persons").All(nil)
+
+		var apiErr *APIError
+		if assert.True(t, errors.As(err, &apiErr)) {
+			assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+			assert.Equal(t, "Service Unavailable", apiErr.Message)
+		}
+		assert.True(t, IsRetryable(err))
+		assert.False(t, IsAuthError(err))
+	})
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		err    *APIError
+		target error
+		want   bool
+	}{
+		{&APIError{StatusCode: 401, Message: "Unauthorized"}, ErrorUnauthorized, true},
+		{&APIError{StatusCode: 403, Message: "Access token / App mismatch"}, ErrorAppMismatch, true},
+		{&APIError{StatusCode: 404, Message: "Not Found"}, ErrorResourceNotFound, true},
+		{&APIError{StatusCode: 400, Message: "Resource not found"}, ErrorResourceNotFound, true},
+		{&APIError{StatusCode: 400, Message: "User not found"}, ErrorUserNotFound, true},
+		{&APIError{StatusCode: 400, Message: "User not found"}, ErrorResourceNotFound, false},
+		{&APIError{StatusCode: 401, Message: "Unauthorized"}, ErrorAppMismatch, false},
+		{&APIError{StatusCode: 400, Message: "unauthorized"}, errors.New("unauthorized"), false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d %s is %s", tt.err.StatusCode, tt.err.Message, tt.target), func(t *testing.T) {
+			assert.Equal(t, tt.want, errors.Is(tt.err, tt.target))
+			assert.Equal(t, tt.want, errors.Is(fmt.Errorf("wrapped: %w", tt.err), tt.target))
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(&APIError{StatusCode: http.StatusTooManyRequests}))
+	assert.True(t, IsRetryable(&APIError{StatusCode: http.StatusBadGateway}))
+	assert.False(t, IsRetryable(&APIError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(errors.New("some error")))
+	assert.False(t, IsRetryable(nil))
+}
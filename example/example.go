@@ -1,16 +1,26 @@
-// Package example demonstrates the use of an extended Collection designed
+// Package example demonstrates the use of a TypedCollection designed
 // for a certain Adalo collection to achieve type safety.
 package example
 
-func init() {
-	var winifred *Person
+import (
+	"context"
+)
 
-	err := Persons.Insert(&PersonInput{
+func init() {
+	winifred, err := Persons.Insert(context.Background(), PersonInput{
 		Name: "Winifred",
 		Age:  34,
-	}, winifred)
+	})
 
 	if err != nil {
 		panic(err)
 	}
+
+	winifred.Age++
+	if _, err := Persons.UpdateRecord(context.Background(), winifred, PersonInput{
+		Name: winifred.Name,
+		Age:  winifred.Age,
+	}); err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,98 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVWriter writes records as CSV with a header row. Relationships are written as related IDs
+// joined by the separator, other arrays and objects as JSON and empty values as empty cells.
+type CSVWriter struct {
+	w       *csv.Writer
+	columns columnSet
+
+	// header is set once the header row was written
+	header bool
+}
+
+// NewCSVWriter returns a CSVWriter writing to w.
+func NewCSVWriter(w io.Writer, opts Options) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), columns: newColumnSet(opts)}
+}
+
+// WriteRecord writes the raw record as CSV row, preceded by the header row for the first record.
+func (w *CSVWriter) WriteRecord(record json.RawMessage) error {
+	if err := w.columns.resolve(record); err != nil {
+		return err
+	}
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	values, err := w.columns.values(record)
+	if err != nil {
+		return err
+	}
+	row := make([]string, len(values))
+	for i, value := range values {
+		if row[i], err = w.format(value); err != nil {
+			return err
+		}
+	}
+	return w.w.Write(row)
+}
+
+// Flush writes buffered rows to the underlying io.Writer. If no record was written, the header row
+// is written as long as the columns are configured or derived from a schema.
+func (w *CSVWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// writeHeader writes the header row unless it was written already or the columns are not known yet.
+func (w *CSVWriter) writeHeader() error {
+	if w.header || len(w.columns.columns) == 0 {
+		return nil
+	}
+	w.header = true
+
+	header := make([]string, len(w.columns.columns))
+	for i, column := range w.columns.columns {
+		header[i] = column.Header
+	}
+	return w.w.Write(header)
+}
+
+// format returns the cell of a value.
+func (w *CSVWriter) format(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		elements := make([]string, len(v))
+		for i, element := range v {
+			var err error
+			if elements[i], err = w.format(relatedID(element)); err != nil {
+				return "", err
+			}
+		}
+		return strings.Join(elements, w.columns.separator()), nil
+	}
+
+	var data bytes.Buffer
+	err := encode(&data, value)
+	return data.String(), err
+}
@@ -0,0 +1,48 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, Options{})
+
+	assert.Nil(t, w.WriteRecord(json.RawMessage(testRecord)))
+	assert.Nil(t, w.WriteRecord(json.RawMessage(`{"id": 2, "Name": "Jane, \"JJ\"", "Photo": "https://example.com/jane.png",
+		"Address": null, "Tasks": [{"id": 5, "Title": "Write"}, {"id": 6}], "Active": false, "Extra": 1}`)))
+	assert.Nil(t, w.Flush())
+
+	assert.Equal(t, "id,Name,Photo,Address.fullAddress,Address.coordinates.latitude,Address.coordinates.longitude,Tasks,Active,created_at,updated_at\n"+
+		"1,John,https://example.com/john.png,1 Main St,40.5,-73.25,3;4,true,2021-03-04T10:00:00.000Z,2021-03-05T10:00:00.000Z\n"+
+		"2,\"Jane, \"\"JJ\"\"\",https://example.com/jane.png,,,,5;6,false,,\n", buf.String())
+
+	assert.Error(t, w.WriteRecord(json.RawMessage(`null`)))
+}
+
+func TestCSVWriter_options(t *testing.T) {
+	schema := &adalo.CollectionSchema{Fields: []adalo.FieldSchema{
+		{Name: "Tags", Type: adalo.FieldRelationship},
+		{Name: "Meta", Type: adalo.FieldText},
+	}}
+
+	var buf bytes.Buffer
+	w := NewCSVWriter(&buf, Options{Schema: schema, Separator: "|"})
+
+	// the header is written without records
+	assert.Nil(t, w.Flush())
+	assert.Equal(t, "id,Tags,Meta,created_at,updated_at\n", buf.String())
+
+	assert.Nil(t, w.WriteRecord(json.RawMessage(`{"id": 1, "Tags": ["a", "b"], "Meta": {"source": "A & B"}}`)))
+	assert.Nil(t, w.Flush())
+	assert.Equal(t, "id,Tags,Meta,created_at,updated_at\n1,a|b,\"{\"\"source\"\":\"\"A & B\"\"}\",,\n", buf.String())
+
+	// nothing is written without columns
+	buf.Reset()
+	assert.Nil(t, NewCSVWriter(&buf, Options{}).Flush())
+	assert.Empty(t, buf.String())
+}
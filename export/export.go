@@ -0,0 +1,272 @@
+// Package export writes the records of Adalo collections as CSV or newline-delimited JSON (NDJSON),
+// e.g. for spreadsheets or data pipelines.
+//
+// Records are written one page at a time, so exports of large collections need little memory.
+// Images, files and locations are flattened into one column per configured property, relationships
+// into a single column listing the related IDs.
+//
+//	n, err := export.WriteCollection(ctx, collection, export.NewCSVWriter(os.Stdout, export.Options{}))
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/internal/rawrecord"
+	"strings"
+)
+
+// DefaultSeparator joins the related IDs of relationships in CSV exports unless configured otherwise.
+const DefaultSeparator = ";"
+
+// DefaultProperties are the properties of images, files and locations exported as columns unless configured otherwise.
+var DefaultProperties = map[adalo.FieldType][]string{
+	adalo.FieldImage:    {"url"},
+	adalo.FieldFile:     {"url"},
+	adalo.FieldLocation: {"fullAddress", "coordinates.latitude", "coordinates.longitude"},
+}
+
+// Column is a column of an export.
+type Column struct {
+	// Header is the name of the column in the CSV header and the key in NDJSON objects
+	Header string
+
+	// Field is the name of the field the value is taken from
+	Field string
+
+	// Property is the dot-separated path of the value in an image, file or location object,
+	// e.g. url or coordinates.latitude. The whole field value is exported if it is empty.
+	Property string
+}
+
+// Options configures the columns of an export.
+type Options struct {
+	// Columns lists the exported columns in order. If empty, the columns are derived from Schema
+	// or, without schema, from the fields of the first record in the order returned by Adalo.
+	Columns []Column
+
+	// Schema of the exported collection
+	Schema *adalo.CollectionSchema
+
+	// Properties lists the properties of images, files and locations exported as separate columns
+	// when the columns are derived, by field type. Defaults to DefaultProperties.
+	Properties map[adalo.FieldType][]string
+
+	// Separator joins the related IDs of relationships in CSV exports, defaults to DefaultSeparator
+	Separator string
+}
+
+// Writer writes records in an export format.
+type Writer interface {
+	// WriteRecord writes the raw record
+	WriteRecord(record json.RawMessage) error
+
+	// Flush writes buffered data to the underlying io.Writer
+	Flush() error
+}
+
+// WriteCollection writes all records of the collection to w page by page and flushes w.
+// It returns the number of written records.
+func WriteCollection(ctx context.Context, c *adalo.Collection, w Writer) (int, error) {
+	count := 0
+	// relationships are exported as IDs, so related records are not loaded
+	it := c.WithoutIncludes().Iterate(ctx, adalo.ListOptions{})
+	for it.Next() {
+		if err := w.WriteRecord(it.Record()); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		return count, err
+	}
+	return count, w.Flush()
+}
+
+// SchemaColumns returns the columns of the id, the fields of the schema and the created_at and updated_at fields.
+// Images, files and locations are flattened into a column per property, see Options.Properties.
+func SchemaColumns(schema *adalo.CollectionSchema, properties map[adalo.FieldType][]string) []Column {
+	if properties == nil {
+		properties = DefaultProperties
+	}
+
+	columns := []Column{{Header: "id", Field: "id"}}
+	for _, field := range schema.Fields {
+		columns = append(columns, fieldColumns(field.Name, properties[field.Type])...)
+	}
+	return append(columns, Column{Header: "created_at", Field: "created_at"}, Column{Header: "updated_at", Field: "updated_at"})
+}
+
+// RecordColumns returns the columns of the fields of the raw record in their order in the record.
+// The types of images, files and locations are recognized from their values, see Options.Properties.
+func RecordColumns(record json.RawMessage, properties map[adalo.FieldType][]string) ([]Column, error) {
+	if properties == nil {
+		properties = DefaultProperties
+	}
+
+	keys, err := rawrecord.Keys(record)
+	if err != nil {
+		return nil, err
+	}
+	values, err := decodeRecord(record)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []Column
+	for _, key := range keys {
+		columns = append(columns, fieldColumns(key, properties[valueType(values[key])])...)
+	}
+	return columns, nil
+}
+
+// fieldColumns returns the columns of a field exporting the properties. A single property is exported
+// in a column named after the field, several properties in columns named field.property.
+func fieldColumns(field string, properties []string) []Column {
+	switch len(properties) {
+	case 0:
+		return []Column{{Header: field, Field: field}}
+	case 1:
+		return []Column{{Header: field, Field: field, Property: properties[0]}}
+	}
+
+	columns := make([]Column, len(properties))
+	for i, property := range properties {
+		columns[i] = Column{Header: field + "." + property, Field: field, Property: property}
+	}
+	return columns
+}
+
+// valueType returns the type of image, file and location values. Files cannot be told apart from images,
+// so objects with an url are reported as image. It returns an empty type for all other values.
+func valueType(value interface{}) adalo.FieldType {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if _, ok := object["url"]; ok {
+		return adalo.FieldImage
+	}
+	if _, ok := object["coordinates"]; ok {
+		return adalo.FieldLocation
+	}
+	if _, ok := object["fullAddress"]; ok {
+		return adalo.FieldLocation
+	}
+	return ""
+}
+
+// columnSet resolves the columns of a writer from its options and the first record.
+type columnSet struct {
+	opts    Options
+	columns []Column
+}
+
+// newColumnSet returns a columnSet for the options. The columns are known immediately if
+// they are configured or derived from a schema.
+func newColumnSet(opts Options) columnSet {
+	set := columnSet{opts: opts, columns: opts.Columns}
+	if len(set.columns) == 0 && opts.Schema != nil {
+		set.columns = SchemaColumns(opts.Schema, opts.Properties)
+	}
+	return set
+}
+
+// resolve derives the columns from the record unless they are known already.
+func (s *columnSet) resolve(record json.RawMessage) error {
+	if len(s.columns) > 0 {
+		return nil
+	}
+	columns, err := RecordColumns(record, s.opts.Properties)
+	if err != nil {
+		return err
+	}
+	s.columns = columns
+	return nil
+}
+
+// values returns the values of the columns in the raw record.
+func (s *columnSet) values(record json.RawMessage) ([]interface{}, error) {
+	fields, err := decodeRecord(record)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(s.columns))
+	for i, column := range s.columns {
+		values[i] = property(fields[column.Field], column.Property)
+	}
+	return values, nil
+}
+
+// separator returns the configured separator of related IDs.
+func (s *columnSet) separator() string {
+	if s.opts.Separator == "" {
+		return DefaultSeparator
+	}
+	return s.opts.Separator
+}
+
+// property returns the value at the dot-separated path in value, or nil if it does not exist.
+// Adalo may return images and files as URL and locations as address string, which are returned
+// for the url and fullAddress properties.
+func property(value interface{}, path string) interface{} {
+	if path == "" {
+		return value
+	}
+	if s, ok := value.(string); ok {
+		if path == "url" || path == "fullAddress" {
+			return s
+		}
+		return nil
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// relatedID returns the id of related records that were loaded with Collection.Include
+// and all other values unchanged.
+func relatedID(value interface{}) interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		if id, ok := object["id"]; ok {
+			return id
+		}
+	}
+	return value
+}
+
+// encode appends the JSON encoding of value to buf without escaping HTML characters,
+// which would make texts like "A & B" hard to read.
+func encode(buf *bytes.Buffer, value interface{}) error {
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	buf.Write(bytes.TrimSuffix(data.Bytes(), []byte("\n")))
+	return nil
+}
+
+// decodeRecord decodes the raw record, keeping numbers as json.Number.
+func decodeRecord(record json.RawMessage) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(record))
+	decoder.UseNumber()
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("record is not a JSON object: %s", record)
+	}
+	return fields, nil
+}
@@ -0,0 +1,151 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/be-foo/adalo-sdk-go/adalotest/testclient"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// testRecord is a record with a field of every flattened type.
+const testRecord = `{
+	"id": 1,
+	"Name": "John",
+	"Photo": {"url": "https://example.com/john.png", "filename": "john.png", "size": 1024},
+	"Address": {"fullAddress": "1 Main St", "coordinates": {"latitude": 40.5, "longitude": -73.25}},
+	"Tasks": [3, 4],
+	"Active": true,
+	"created_at": "2021-03-04T10:00:00.000Z",
+	"updated_at": "2021-03-05T10:00:00.000Z"
+}`
+
+func TestSchemaColumns(t *testing.T) {
+	schema := &adalo.CollectionSchema{Fields: []adalo.FieldSchema{
+		{Name: "Name", Type: adalo.FieldText},
+		{Name: "Photo", Type: adalo.FieldImage},
+		{Name: "Address", Type: adalo.FieldLocation},
+		{Name: "Tasks", Type: adalo.FieldRelationship},
+	}}
+
+	assert.Equal(t, []Column{
+		{Header: "id", Field: "id"},
+		{Header: "Name", Field: "Name"},
+		{Header: "Photo", Field: "Photo", Property: "url"},
+		{Header: "Address.fullAddress", Field: "Address", Property: "fullAddress"},
+		{Header: "Address.coordinates.latitude", Field: "Address", Property: "coordinates.latitude"},
+		{Header: "Address.coordinates.longitude", Field: "Address", Property: "coordinates.longitude"},
+		{Header: "Tasks", Field: "Tasks"},
+		{Header: "created_at", Field: "created_at"},
+		{Header: "updated_at", Field: "updated_at"},
+	}, SchemaColumns(schema, nil))
+
+	columns := SchemaColumns(schema, map[adalo.FieldType][]string{adalo.FieldImage: {"url", "size"}})
+	assert.Equal(t, []Column{
+		{Header: "id", Field: "id"},
+		{Header: "Name", Field: "Name"},
+		{Header: "Photo.url", Field: "Photo", Property: "url"},
+		{Header: "Photo.size", Field: "Photo", Property: "size"},
+		{Header: "Address", Field: "Address"},
+		{Header: "Tasks", Field: "Tasks"},
+		{Header: "created_at", Field: "created_at"},
+		{Header: "updated_at", Field: "updated_at"},
+	}, columns)
+}
+
+func TestRecordColumns(t *testing.T) {
+	columns, err := RecordColumns(json.RawMessage(testRecord), nil)
+	assert.Nil(t, err)
+
+	var headers []string
+	for _, column := range columns {
+		headers = append(headers, column.Header)
+	}
+	assert.Equal(t, []string{"id", "Name", "Photo", "Address.fullAddress", "Address.coordinates.latitude",
+		"Address.coordinates.longitude", "Tasks", "Active", "created_at", "updated_at"}, headers)
+
+	_, err = RecordColumns(json.RawMessage(`[1, 2]`), nil)
+	assert.Error(t, err)
+}
+
+func TestProperty(t *testing.T) {
+	image := map[string]interface{}{"url": "https://example.com/a.png"}
+	location := map[string]interface{}{"coordinates": map[string]interface{}{"latitude": 1.5}}
+
+	assert.Equal(t, image, property(image, ""))
+	assert.Equal(t, "https://example.com/a.png", property(image, "url"))
+	assert.Nil(t, property(image, "size"))
+	assert.Equal(t, 1.5, property(location, "coordinates.latitude"))
+	assert.Nil(t, property(location, "name.first"))
+	assert.Nil(t, property(nil, "url"))
+
+	// images and locations given as string
+	assert.Equal(t, "https://example.com/b.png", property("https://example.com/b.png", "url"))
+	assert.Equal(t, "1 Main St", property("1 Main St", "fullAddress"))
+	assert.Nil(t, property("1 Main St", "coordinates.latitude"))
+}
+
+// failingWriter is a Writer failing on the record with the given index.
+type failingWriter struct {
+	fail    int
+	records int
+}
+
+func (w *failingWriter) WriteRecord(record json.RawMessage) error {
+	if w.records == w.fail {
+		return errors.New("disk full")
+	}
+	w.records++
+	return nil
+}
+
+func (w *failingWriter) Flush() error {
+	return nil
+}
+
+func TestWriteCollection(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	for i := 1; i <= 250; i++ {
+		srv.Seed("persons", map[string]interface{}{"Name": fmt.Sprintf("Person %d", i), "Tasks": []int{i}})
+	}
+	client := testclient.New(srv)
+	persons := client.Collection("persons")
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	n, err := WriteCollection(ctx, persons, NewCSVWriter(&buf, Options{Columns: []Column{
+		{Header: "ID", Field: "id"},
+		{Header: "Name", Field: "Name"},
+		{Header: "Tasks", Field: "Tasks"},
+	}}))
+	assert.Nil(t, err)
+	assert.Equal(t, 250, n)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 251)
+	assert.Equal(t, "ID,Name,Tasks", lines[0])
+	assert.Equal(t, "250,Person 250,250", lines[250])
+
+	// records are fetched page by page
+	gets := 0
+	for _, req := range srv.Requests() {
+		if req.Method == "GET" {
+			gets++
+		}
+	}
+	assert.Equal(t, 3, gets)
+
+	n, err = WriteCollection(ctx, persons, &failingWriter{fail: 120})
+	assert.EqualError(t, err, "disk full")
+	assert.Equal(t, 120, n)
+
+	_, err = WriteCollection(ctx, client.Collection("tasks"), NewNDJSONWriter(&buf, Options{}))
+	assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+}
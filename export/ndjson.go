@@ -0,0 +1,70 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+// NDJSONWriter writes records as newline-delimited JSON, one object per line with a key per column
+// in the order of the columns. Values keep their JSON types, relationships are written as arrays of related IDs.
+type NDJSONWriter struct {
+	w       *bufio.Writer
+	columns columnSet
+}
+
+// NewNDJSONWriter returns a NDJSONWriter writing to w.
+func NewNDJSONWriter(w io.Writer, opts Options) *NDJSONWriter {
+	return &NDJSONWriter{w: bufio.NewWriter(w), columns: newColumnSet(opts)}
+}
+
+// WriteRecord writes the raw record as a line of JSON.
+func (w *NDJSONWriter) WriteRecord(record json.RawMessage) error {
+	if err := w.columns.resolve(record); err != nil {
+		return err
+	}
+	values, err := w.columns.values(record)
+	if err != nil {
+		return err
+	}
+
+	// the object is encoded by hand, since maps would not keep the order of the columns
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, column := range w.columns.columns {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		if err := encode(&line, column.Header); err != nil {
+			return err
+		}
+		line.WriteByte(':')
+		if err := encode(&line, relatedIDs(values[i])); err != nil {
+			return err
+		}
+	}
+	line.WriteString("}\n")
+
+	_, err = w.w.Write(line.Bytes())
+	return err
+}
+
+// Flush writes buffered lines to the underlying io.Writer.
+func (w *NDJSONWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// relatedIDs replaces related records loaded with Collection.Include by their IDs.
+func relatedIDs(value interface{}) interface{} {
+	elements, ok := value.([]interface{})
+	if !ok {
+		return value
+	}
+
+	ids := make([]interface{}, len(elements))
+	for i, element := range elements {
+		ids[i] = relatedID(element)
+	}
+	return ids
+}
@@ -0,0 +1,38 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNDJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf, Options{})
+
+	assert.Nil(t, w.WriteRecord(json.RawMessage(testRecord)))
+	assert.Nil(t, w.WriteRecord(json.RawMessage(`{"id": 12345678901234567890, "Name": "A & B", "Tasks": [{"id": 5, "Title": "Write"}]}`)))
+	assert.Nil(t, w.Flush())
+
+	assert.Equal(t, `{"id":1,"Name":"John","Photo":"https://example.com/john.png","Address.fullAddress":"1 Main St",`+
+		`"Address.coordinates.latitude":40.5,"Address.coordinates.longitude":-73.25,"Tasks":[3,4],"Active":true,`+
+		`"created_at":"2021-03-04T10:00:00.000Z","updated_at":"2021-03-05T10:00:00.000Z"}`+"\n"+
+		`{"id":12345678901234567890,"Name":"A & B","Photo":null,"Address.fullAddress":null,`+
+		`"Address.coordinates.latitude":null,"Address.coordinates.longitude":null,"Tasks":[5],"Active":null,`+
+		`"created_at":null,"updated_at":null}`+"\n", buf.String())
+
+	assert.Error(t, w.WriteRecord(json.RawMessage(`"text"`)))
+}
+
+func TestNDJSONWriter_columns(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONWriter(&buf, Options{Columns: []Column{
+		{Header: "name", Field: "Name"},
+		{Header: "lat", Field: "Address", Property: "coordinates.latitude"},
+	}})
+
+	assert.Nil(t, w.WriteRecord(json.RawMessage(testRecord)))
+	assert.Nil(t, w.Flush())
+	assert.Equal(t, `{"name":"John","lat":40.5}`+"\n", buf.String())
+}
@@ -1,5 +1,14 @@
 module github.com/be-foo/adalo-sdk-go
 
-go 1.15
+// Go 1.24 is the minimum version, since Optional and the generated inputs rely on the omitzero json option.
+go 1.24
 
-require github.com/stretchr/testify v1.6.1
+require (
+	github.com/stretchr/testify v1.6.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+)
@@ -0,0 +1,140 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/types"
+	"strconv"
+	"strings"
+)
+
+// convert converts a value read from the input to the value of a field of the given type.
+// Empty strings are converted to nil for all types except text. Values of fields without type are not converted.
+func convert(value interface{}, fieldType adalo.FieldType, separator string) (interface{}, error) {
+	if s, ok := value.(string); ok && fieldType != adalo.FieldText && fieldType != "" {
+		if value = strings.TrimSpace(s); value == "" {
+			if fieldType == adalo.FieldRelationship {
+				return []int{}, nil
+			}
+			return nil, nil
+		}
+	}
+	if value == nil {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case adalo.FieldText:
+		return convertText(value)
+	case adalo.FieldNumber:
+		return convertNumber(value)
+	case adalo.FieldBoolean:
+		return convertBoolean(value)
+	case adalo.FieldDate:
+		var d types.Date
+		if err := unmarshalString(value, &d); err != nil {
+			return nil, err
+		}
+		return d.String(), nil
+	case adalo.FieldDateTime:
+		var dt types.DateTime
+		if err := unmarshalString(value, &dt); err != nil {
+			return nil, err
+		}
+		return dt.String(), nil
+	case adalo.FieldImage, adalo.FieldFile, adalo.FieldLocation:
+		// Adalo accepts the URL of images and files and the address of locations as string
+		switch value.(type) {
+		case string, map[string]interface{}:
+			return value, nil
+		}
+		return nil, fmt.Errorf("invalid %s %v", fieldType, value)
+	case adalo.FieldRelationship:
+		return convertRelationship(value, separator)
+	}
+	return value, nil
+}
+
+// convertText converts strings, numbers and booleans to text.
+func convertText(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return nil, fmt.Errorf("invalid text %v", value)
+}
+
+// convertNumber converts numbers and numeric strings to json.Number.
+func convertNumber(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v, nil
+	case string:
+		// json.Valid rejects numbers Go accepts but JSON does not, like NaN or 0x1F
+		if _, err := strconv.ParseFloat(v, 64); err != nil || !json.Valid([]byte(v)) {
+			return nil, fmt.Errorf("invalid number %q", v)
+		}
+		return json.Number(v), nil
+	}
+	return nil, fmt.Errorf("invalid number %v", value)
+}
+
+// convertBoolean converts booleans and strings like true, false, 1 and 0 to bool.
+func convertBoolean(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string, json.Number:
+		b, err := strconv.ParseBool(fmt.Sprint(v))
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean %q", v)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("invalid boolean %v", value)
+}
+
+// convertRelationship converts arrays of IDs and strings of IDs joined by separator to a slice of IDs.
+func convertRelationship(value interface{}, separator string) (interface{}, error) {
+	var elements []interface{}
+	switch v := value.(type) {
+	case []interface{}:
+		elements = v
+	case string:
+		for _, s := range strings.Split(v, separator) {
+			elements = append(elements, strings.TrimSpace(s))
+		}
+	case json.Number:
+		elements = []interface{}{v}
+	default:
+		return nil, fmt.Errorf("invalid relationship %v", value)
+	}
+
+	ids := make([]int, 0, len(elements))
+	for _, element := range elements {
+		id, err := strconv.Atoi(fmt.Sprint(element))
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid related id %v", element)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// unmarshalString decodes a string value into result, which unmarshals JSON strings.
+func unmarshalString(value interface{}, result json.Unmarshaler) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("invalid value %v", value)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return result.UnmarshalJSON(data)
+}
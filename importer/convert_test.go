@@ -0,0 +1,74 @@
+package importer
+
+import (
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	image := map[string]interface{}{"url": "https://example.com/a.png"}
+
+	tests := []struct {
+		value     interface{}
+		fieldType adalo.FieldType
+		expected  interface{}
+	}{
+		{"John", adalo.FieldText, "John"},
+		{"", adalo.FieldText, ""},
+		{json.Number("42"), adalo.FieldText, "42"},
+		{true, adalo.FieldText, "true"},
+		{" 42.5 ", adalo.FieldNumber, json.Number("42.5")},
+		{json.Number("-1e3"), adalo.FieldNumber, json.Number("-1e3")},
+		{"", adalo.FieldNumber, nil},
+		{"TRUE", adalo.FieldBoolean, true},
+		{"0", adalo.FieldBoolean, false},
+		{json.Number("1"), adalo.FieldBoolean, true},
+		{false, adalo.FieldBoolean, false},
+		{"2021-03-04", adalo.FieldDate, "2021-03-04"},
+		{"2021-03-04T23:00:00Z", adalo.FieldDate, "2021-03-04"},
+		{"2021-03-04T10:00:00+02:00", adalo.FieldDateTime, "2021-03-04T08:00:00.000Z"},
+		{"2021-03-04", adalo.FieldDateTime, "2021-03-04T00:00:00.000Z"},
+		{"https://example.com/a.png", adalo.FieldImage, "https://example.com/a.png"},
+		{image, adalo.FieldFile, image},
+		{"1 Main St", adalo.FieldLocation, "1 Main St"},
+		{"3; 4", adalo.FieldRelationship, []int{3, 4}},
+		{"", adalo.FieldRelationship, []int{}},
+		{[]interface{}{json.Number("5")}, adalo.FieldRelationship, []int{5}},
+		{json.Number("6"), adalo.FieldRelationship, []int{6}},
+		{nil, adalo.FieldNumber, nil},
+		{" kept ", "", " kept "},
+		{image, "", image},
+	}
+	for _, test := range tests {
+		converted, err := convert(test.value, test.fieldType, ";")
+		assert.Nil(t, err, "%v as %s", test.value, test.fieldType)
+		assert.Equal(t, test.expected, converted, "%v as %s", test.value, test.fieldType)
+	}
+}
+
+func TestConvert_invalid(t *testing.T) {
+	tests := []struct {
+		value     interface{}
+		fieldType adalo.FieldType
+	}{
+		{[]interface{}{"a"}, adalo.FieldText},
+		{"12,5", adalo.FieldNumber},
+		{"NaN", adalo.FieldNumber},
+		{"0x1F", adalo.FieldNumber},
+		{true, adalo.FieldNumber},
+		{"maybe", adalo.FieldBoolean},
+		{"04.03.2021", adalo.FieldDate},
+		{json.Number("20210304"), adalo.FieldDate},
+		{"yesterday", adalo.FieldDateTime},
+		{json.Number("1"), adalo.FieldImage},
+		{"3;x", adalo.FieldRelationship},
+		{"3;-4", adalo.FieldRelationship},
+		{true, adalo.FieldRelationship},
+	}
+	for _, test := range tests {
+		_, err := convert(test.value, test.fieldType, ";")
+		assert.Error(t, err, "%v as %s", test.value, test.fieldType)
+	}
+}
@@ -0,0 +1,312 @@
+// Package importer loads rows from CSV or newline-delimited JSON (NDJSON) into an Adalo collection.
+//
+// Columns are mapped to fields and converted to the field types of a schema. Rows failing
+// conversion, validation or the write are rejected and can be written to a RejectWriter
+// without stopping the import. With DryRun, rows are converted and validated, and records
+// are looked up by key, but nothing is written.
+//
+//	result, err := importer.Import(ctx, collection, importer.NewCSVReader(file), importer.Options{
+//		Schema:   &schema.Collections[0],
+//		KeyField: "Email",
+//		Rejected: importer.NewCSVRejectWriter(rejected),
+//	})
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/internal/rawrecord"
+	"io"
+)
+
+// DefaultSeparator separates the related IDs of relationships in text values unless configured otherwise.
+const DefaultSeparator = ";"
+
+// ErrorRequired is the reason of rejecting rows where a required field or the key field is empty.
+var ErrorRequired = errors.New("value is required")
+
+// Mapping maps a column of the input to a field of the collection.
+type Mapping struct {
+	// Column is the name of the column in the CSV header or the key in NDJSON objects
+	Column string
+
+	// Field is the name of the field in Adalo, defaults to Column
+	Field string
+
+	// Type the value is converted to, defaults to the type of the field in the schema.
+	// Values of fields without type are passed unchanged.
+	Type adalo.FieldType
+}
+
+// Options configures an import.
+type Options struct {
+	// Mappings lists the imported columns. If empty, every column is imported into the field of the same name,
+	// except for id, created_at and updated_at and, if a schema is set, for columns that are not in the schema.
+	Mappings []Mapping
+
+	// Schema of the collection, providing the types of the fields
+	Schema *adalo.CollectionSchema
+
+	// Required lists the fields that must not be empty
+	Required []string
+
+	// KeyField is the field identifying existing records, which are updated instead of inserted, see Collection.Upsert.
+	// If empty, all rows are inserted.
+	KeyField string
+
+	// Separator separates the related IDs of relationships in text values, defaults to DefaultSeparator
+	Separator string
+
+	// DryRun converts and validates the rows and looks up existing records without writing
+	DryRun bool
+
+	// Rejected, if not nil, receives the rejected rows
+	Rejected RejectWriter
+
+	// OnRow, if not nil, is called with the outcome of every row, e.g. to report a dry run
+	OnRow func(outcome Outcome)
+}
+
+// Action is the action taken for a row.
+type Action int
+
+// list of actions taken for rows
+const (
+	// ActionInsert inserts the row as a new record
+	ActionInsert Action = iota + 1
+
+	// ActionUpdate updates the existing record with the key of the row
+	ActionUpdate
+
+	// ActionReject rejects the row
+	ActionReject
+)
+
+// String returns the name of the action.
+func (a Action) String() string {
+	switch a {
+	case ActionInsert:
+		return "insert"
+	case ActionUpdate:
+		return "update"
+	case ActionReject:
+		return "reject"
+	}
+	return fmt.Sprintf("Action(%d)", int(a))
+}
+
+// Outcome is the outcome of importing a row.
+type Outcome struct {
+	// Row is the imported row
+	Row *Row
+
+	// Action taken, or that would have been taken in a dry run
+	Action Action
+
+	// ID of the inserted or updated record. In a dry run, it is only set for updates.
+	ID int
+
+	// Err is the reason a row was rejected
+	Err error
+}
+
+// Result counts the rows of an import by action. In a dry run, it counts the actions that would have been taken.
+type Result struct {
+	Inserted int
+	Updated  int
+	Rejected int
+}
+
+// Import reads all rows from r and inserts or updates them in the collection. Rows that fail are rejected,
+// the import only stops when reading fails, the context is done or the credentials are rejected by Adalo.
+// The returned result counts the rows processed until then. Rejected rows are flushed in any case.
+func Import(ctx context.Context, c *adalo.Collection, r Reader, opts Options) (result *Result, err error) {
+	if opts.Rejected != nil {
+		defer func() {
+			err = errors.Join(err, opts.Rejected.Flush())
+		}()
+	}
+
+	result = &Result{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return result, err
+		}
+
+		outcome := importRow(ctx, c, row, opts)
+		if outcome.Err != nil && (ctx.Err() != nil || adalo.IsAuthError(outcome.Err)) {
+			return result, outcome.Err
+		}
+
+		switch outcome.Action {
+		case ActionInsert:
+			result.Inserted++
+		case ActionUpdate:
+			result.Updated++
+		case ActionReject:
+			result.Rejected++
+			if opts.Rejected != nil {
+				if err := opts.Rejected.Reject(row, outcome.Err); err != nil {
+					return result, err
+				}
+			}
+		}
+		if opts.OnRow != nil {
+			opts.OnRow(outcome)
+		}
+	}
+	return result, nil
+}
+
+// importRow converts, validates and writes the row.
+func importRow(ctx context.Context, c *adalo.Collection, row *Row, opts Options) Outcome {
+	if row.Err != nil {
+		return Outcome{Row: row, Action: ActionReject, Err: row.Err}
+	}
+	input, err := opts.input(row)
+	if err != nil {
+		return Outcome{Row: row, Action: ActionReject, Err: err}
+	}
+
+	var record adalo.Record
+	switch {
+	case opts.KeyField == "" && opts.DryRun:
+		return Outcome{Row: row, Action: ActionInsert}
+	case opts.KeyField == "":
+		err = c.InsertContext(ctx, input, &record)
+		return outcome(row, ActionInsert, record.ID, err)
+	case opts.DryRun:
+		id, err := lookup(ctx, c, opts.KeyField, fmt.Sprint(input[opts.KeyField]))
+		if id == 0 {
+			return outcome(row, ActionInsert, 0, err)
+		}
+		return outcome(row, ActionUpdate, id, err)
+	}
+
+	created, err := c.Upsert(ctx, opts.KeyField, fmt.Sprint(input[opts.KeyField]), input, &record)
+	if created {
+		return outcome(row, ActionInsert, record.ID, err)
+	}
+	return outcome(row, ActionUpdate, record.ID, err)
+}
+
+// outcome returns the outcome of the action, or of rejecting the row if err is not nil.
+func outcome(row *Row, action Action, id int, err error) Outcome {
+	if err != nil {
+		return Outcome{Row: row, Action: ActionReject, Err: err}
+	}
+	return Outcome{Row: row, Action: action, ID: id}
+}
+
+// lookup returns the id of the record whose keyField equals keyValue, or 0 if there is no such record.
+// Like Collection.Upsert, it bypasses the cache of a cached collection and returns an error wrapping
+// adalo.ErrorDuplicateKey if more than one record matches.
+func lookup(ctx context.Context, c *adalo.Collection, keyField, keyValue string) (int, error) {
+	page, err := c.Cached(0).Where(keyField, keyValue).List(ctx, adalo.ListOptions{Limit: 2})
+	if err != nil {
+		return 0, err
+	}
+
+	var records []adalo.Record
+	if err := page.Bind(&records); err != nil {
+		return 0, err
+	}
+	switch len(records) {
+	case 0:
+		return 0, nil
+	case 1:
+		return records[0].ID, nil
+	}
+	return 0, fmt.Errorf("%w: %s = %q", adalo.ErrorDuplicateKey, keyField, keyValue)
+}
+
+// input converts the row to the input of a write and validates it.
+func (o Options) input(row *Row) (map[string]interface{}, error) {
+	input := map[string]interface{}{}
+	for _, mapping := range o.mappings(row) {
+		value, ok := row.Values[mapping.Column]
+		if !ok {
+			continue
+		}
+		converted, err := convert(value, mapping.Type, o.separator())
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", mapping.Column, err)
+		}
+		input[mapping.Field] = converted
+	}
+
+	required := o.Required
+	if o.KeyField != "" {
+		required = append([]string{o.KeyField}, required...)
+	}
+	for _, field := range required {
+		if isEmpty(input[field]) {
+			return nil, fmt.Errorf("field %s: %w", field, ErrorRequired)
+		}
+	}
+	return input, nil
+}
+
+// mappings returns the mappings of the options with defaults applied, or the default mappings of the columns of the row.
+func (o Options) mappings(row *Row) []Mapping {
+	fieldTypes := map[string]adalo.FieldType{}
+	if o.Schema != nil {
+		for _, field := range o.Schema.Fields {
+			fieldTypes[field.Name] = field.Type
+		}
+	}
+
+	if len(o.Mappings) == 0 {
+		var mappings []Mapping
+		for _, column := range row.Columns {
+			// the fields Adalo sets for every record are not imported by default
+			if rawrecord.IsSystemField(column) {
+				continue
+			}
+			if _, ok := fieldTypes[column]; o.Schema != nil && !ok {
+				continue
+			}
+			mappings = append(mappings, Mapping{Column: column, Field: column, Type: fieldTypes[column]})
+		}
+		return mappings
+	}
+
+	mappings := make([]Mapping, len(o.Mappings))
+	for i, mapping := range o.Mappings {
+		if mapping.Field == "" {
+			mapping.Field = mapping.Column
+		}
+		if mapping.Type == "" {
+			mapping.Type = fieldTypes[mapping.Field]
+		}
+		mappings[i] = mapping
+	}
+	return mappings
+}
+
+// separator returns the configured separator of related IDs.
+func (o Options) separator() string {
+	if o.Separator == "" {
+		return DefaultSeparator
+	}
+	return o.Separator
+}
+
+// isEmpty reports whether a converted value is empty.
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []int:
+		return len(v) == 0
+	}
+	return false
+}
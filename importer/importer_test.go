@@ -0,0 +1,263 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/be-foo/adalo-sdk-go/adalotest/testclient"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSchema describes the persons collection of the tests.
+var testSchema = &adalo.CollectionSchema{Name: "Person", ID: "persons", Fields: []adalo.FieldSchema{
+	{Name: "Email", Type: adalo.FieldText},
+	{Name: "Name", Type: adalo.FieldText},
+	{Name: "Age", Type: adalo.FieldNumber},
+	{Name: "Active", Type: adalo.FieldBoolean},
+	{Name: "Tasks", Type: adalo.FieldRelationship},
+}}
+
+// testCSV contains a row of every outcome when imported with the key field Email.
+const testCSV = `id,Email,Name,Age,Active,Tasks,Notes
+1,john@example.com,John,22,true,1;2,updated
+,jane@example.com,Jane,30,false,,inserted
+,richard@example.com,Richard,old,true,,invalid number
+,,Nobody,1,true,,missing key
+,dup@example.com,Dup,1,true,,duplicate key
+`
+
+// failingRejectWriter discards rejected rows and fails to flush them with err.
+type failingRejectWriter struct {
+	err error
+}
+
+func (w failingRejectWriter) Reject(row *Row, reason error) error {
+	return nil
+}
+
+func (w failingRejectWriter) Flush() error {
+	return w.err
+}
+
+// testPersons are the records of the persons collection, which contains John and two records with the same Email.
+var testPersons = []interface{}{
+	map[string]interface{}{"Email": "john@example.com", "Name": "John", "Age": 21},
+	map[string]interface{}{"Email": "dup@example.com"},
+	map[string]interface{}{"Email": "dup@example.com"},
+}
+
+func TestImport(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+
+	var rejected bytes.Buffer
+	var outcomes []Outcome
+	result, err := Import(context.Background(), persons, NewCSVReader(strings.NewReader(testCSV)), Options{
+		Schema:   testSchema,
+		KeyField: "Email",
+		Rejected: NewCSVRejectWriter(&rejected),
+		OnRow: func(outcome Outcome) {
+			outcomes = append(outcomes, outcome)
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 1, Updated: 1, Rejected: 3}, result)
+
+	if assert.Len(t, outcomes, 5) {
+		assert.Equal(t, ActionUpdate, outcomes[0].Action)
+		assert.Equal(t, 1, outcomes[0].ID)
+		assert.Equal(t, ActionInsert, outcomes[1].Action)
+		assert.Equal(t, 4, outcomes[1].ID)
+		assert.Equal(t, ActionReject, outcomes[2].Action)
+		assert.EqualError(t, outcomes[2].Err, `column Age: invalid number "old"`)
+		assert.True(t, errors.Is(outcomes[3].Err, ErrorRequired))
+		assert.True(t, errors.Is(outcomes[4].Err, adalo.ErrorDuplicateKey))
+		assert.Equal(t, 6, outcomes[4].Row.Line)
+	}
+
+	john, _ := srv.Record("persons", 1)
+	assert.Equal(t, json.Number("22"), john["Age"])
+	assert.Equal(t, true, john["Active"])
+	assert.NotContains(t, john, "Notes")
+
+	jane, _ := srv.Record("persons", 4)
+	assert.Equal(t, "Jane", jane["Name"])
+	assert.Len(t, srv.Records("persons"), 4)
+
+	assert.Equal(t, "id,Email,Name,Age,Active,Tasks,Notes,import_error\n"+
+		",richard@example.com,Richard,old,true,,invalid number,\"column Age: invalid number \"\"old\"\"\"\n"+
+		",,Nobody,1,true,,missing key,field Email: value is required\n"+
+		",dup@example.com,Dup,1,true,,duplicate key,\"more than one record matches the key: Email = \"\"dup@example.com\"\"\"\n",
+		rejected.String())
+}
+
+func TestImport_shortRow(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+
+	var rejected bytes.Buffer
+	result, err := Import(context.Background(), persons, NewCSVReader(strings.NewReader(
+		"Email,Name,Age\nshort@example.com,Short\njane@example.com,Jane,30\n")), Options{
+		Schema:   testSchema,
+		KeyField: "Email",
+		Rejected: NewCSVRejectWriter(&rejected),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 1, Rejected: 1}, result)
+	assert.Equal(t, "Email,Name,Age,import_error\n"+
+		"short@example.com,Short,,wrong number of fields: 2 values for 3 columns\n", rejected.String())
+	assert.Len(t, srv.Records("persons"), 4)
+}
+
+func TestImport_dryRun(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+	srv.ResetRequests()
+
+	var actions []Action
+	result, err := Import(context.Background(), persons, NewCSVReader(strings.NewReader(testCSV)), Options{
+		Schema:   testSchema,
+		KeyField: "Email",
+		DryRun:   true,
+		OnRow: func(outcome Outcome) {
+			actions = append(actions, outcome.Action)
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 1, Updated: 1, Rejected: 3}, result)
+	assert.Equal(t, []Action{ActionUpdate, ActionInsert, ActionReject, ActionReject, ActionReject}, actions)
+
+	for _, req := range srv.Requests() {
+		assert.Equal(t, "GET", req.Method)
+	}
+	john, _ := srv.Record("persons", 1)
+	assert.Equal(t, json.Number("21"), john["Age"])
+
+	// without key field, no requests are needed
+	srv.ResetRequests()
+	result, err = Import(context.Background(), persons, NewCSVReader(strings.NewReader(testCSV)), Options{Schema: testSchema, DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 4, Rejected: 1}, result)
+	assert.Empty(t, srv.Requests())
+}
+
+func TestImport_dryRunCached(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+	cached := persons.Cached(time.Minute)
+
+	// caches the empty result of the lookup
+	page, err := cached.Where("Email", "jane@example.com").List(context.Background(), adalo.ListOptions{Limit: 2})
+	assert.Nil(t, err)
+	assert.Empty(t, page.Records)
+	srv.Seed("persons", map[string]interface{}{"Email": "jane@example.com"})
+
+	var outcomes []Outcome
+	result, err := Import(context.Background(), cached, NewCSVReader(strings.NewReader("Email,Name\njane@example.com,Jane\n")), Options{
+		Schema:   testSchema,
+		KeyField: "Email",
+		DryRun:   true,
+		OnRow: func(outcome Outcome) {
+			outcomes = append(outcomes, outcome)
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Updated: 1}, result)
+	if assert.Len(t, outcomes, 1) {
+		assert.Equal(t, 4, outcomes[0].ID)
+	}
+}
+
+func TestImport_mappings(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+
+	input := `{"mail": "max@example.com", "years": "40", "tags": "a;b"}` + "\n" + `{"mail": "moritz@example.com"}`
+	result, err := Import(context.Background(), persons, NewNDJSONReader(strings.NewReader(input)), Options{
+		Schema: testSchema,
+		Mappings: []Mapping{
+			{Column: "mail", Field: "Email"},
+			{Column: "years", Field: "Age"},
+			{Column: "tags", Type: adalo.FieldText},
+		},
+		Required: []string{"Age"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 1, Rejected: 1}, result)
+
+	max, _ := srv.Record("persons", 4)
+	assert.Equal(t, "max@example.com", max["Email"])
+	assert.Equal(t, json.Number("40"), max["Age"])
+	assert.Equal(t, "a;b", max["tags"])
+}
+
+func TestImport_errors(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+
+	unauthorized := adalo.NewClient("invalid", "app", adalo.WithCollectionsBaseURL(srv.URL)).Collection("persons")
+	result, err := Import(context.Background(), unauthorized, NewCSVReader(strings.NewReader(testCSV)), Options{Schema: testSchema})
+	assert.True(t, adalo.IsAuthError(err))
+	assert.Equal(t, &Result{}, result)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	persons := testclient.New(srv).Collection("persons")
+	_, err = Import(ctx, persons, NewCSVReader(strings.NewReader(testCSV)), Options{Schema: testSchema})
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	// rejected rows are flushed when the import stops
+	var rejected bytes.Buffer
+	_, err = Import(context.Background(), unauthorized, NewNDJSONReader(strings.NewReader("not json\n{\"Name\": \"A\"}\n")), Options{
+		Schema:   testSchema,
+		Rejected: NewNDJSONRejectWriter(&rejected),
+	})
+	assert.True(t, adalo.IsAuthError(err))
+	assert.Contains(t, rejected.String(), `{"import_error":"line 1: invalid character`)
+
+	flushErr := errors.New("disk full")
+	_, err = Import(context.Background(), persons, NewCSVReader(strings.NewReader("Name\nA\n")), Options{
+		Schema:   testSchema,
+		Rejected: failingRejectWriter{flushErr},
+	})
+	assert.True(t, errors.Is(err, flushErr))
+	assert.Len(t, srv.Records("persons"), 4)
+}
+
+func TestImport_malformedLines(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", testPersons...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+
+	var rejected bytes.Buffer
+	result, err := Import(context.Background(), persons, NewNDJSONReader(strings.NewReader("{\"Name\": \"A\"}\nnot json\n{\"Name\": \"B\"}\n")), Options{
+		Schema:   testSchema,
+		Rejected: NewNDJSONRejectWriter(&rejected),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 2, Rejected: 1}, result)
+	assert.Contains(t, rejected.String(), `{"import_error":"line 2: invalid character`)
+
+	result, err = Import(context.Background(), persons, NewCSVReader(strings.NewReader("Name,Age\nC,1\nD\"x,2\nE,3\n")), Options{Schema: testSchema})
+	assert.Nil(t, err)
+	assert.Equal(t, &Result{Inserted: 2, Rejected: 1}, result)
+	assert.Len(t, srv.Records("persons"), 7)
+}
+
+func TestAction_String(t *testing.T) {
+	assert.Equal(t, "insert", ActionInsert.String())
+	assert.Equal(t, "update", ActionUpdate.String())
+	assert.Equal(t, "reject", ActionReject.String())
+	assert.Equal(t, "Action(0)", Action(0).String())
+}
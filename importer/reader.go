@@ -0,0 +1,157 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Row is a row read from the input of an import.
+type Row struct {
+	// Line is the line number of the row in the input, starting at 1
+	Line int
+
+	// Columns lists the columns of the row in their order in the input
+	Columns []string
+
+	// Values holds the values by column. Values read from CSV are strings,
+	// values read from NDJSON keep their JSON type with numbers as json.Number.
+	Values map[string]interface{}
+
+	// Err is the reason the row cannot be imported although it was read, e.g. a malformed line
+	// or a CSV row with the wrong number of values. Import rejects rows with Err instead of stopping.
+	Err error
+}
+
+// Reader reads the rows of an import.
+type Reader interface {
+	// Read returns the next row, or io.EOF if all rows were read
+	Read() (*Row, error)
+}
+
+// CSVReader reads rows from CSV with a header row naming the columns.
+type CSVReader struct {
+	r      *csv.Reader
+	header []string
+}
+
+// NewCSVReader returns a CSVReader reading from r.
+func NewCSVReader(r io.Reader) *CSVReader {
+	reader := csv.NewReader(r)
+	reader.ReuseRecord = true
+	// the number of values is checked by Read, so rows with the wrong number can be rejected
+	reader.FieldsPerRecord = -1
+	return &CSVReader{r: reader}
+}
+
+// Read returns the next row. Rows with more or fewer values than the header are returned
+// with Err wrapping csv.ErrFieldCount, missing values are left out of the Values.
+// Rows that cannot be parsed, e.g. due to a stray quote, are returned without values and
+// with the *csv.ParseError as Err.
+func (r *CSVReader) Read() (*Row, error) {
+	if r.header == nil {
+		header, err := r.r.Read()
+		if err != nil {
+			return nil, err
+		}
+		r.header = append([]string(nil), header...)
+		// spreadsheet applications prefix the file with a byte order mark
+		r.header[0] = strings.TrimPrefix(r.header[0], "\ufeff")
+	}
+
+	record, err := r.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &Row{Line: parseErr.StartLine, Columns: r.header, Err: err}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	line, _ := r.r.FieldPos(0)
+
+	row := &Row{Line: line, Columns: r.header, Values: make(map[string]interface{}, len(record))}
+	for i, value := range record {
+		if i < len(r.header) {
+			row.Values[r.header[i]] = value
+		}
+	}
+	if len(record) != len(r.header) {
+		row.Err = fmt.Errorf("%w: %d values for %d columns", csv.ErrFieldCount, len(record), len(r.header))
+	}
+	return row, nil
+}
+
+// NDJSONReader reads rows from newline-delimited JSON with an object per line. Empty lines are skipped.
+type NDJSONReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// NewNDJSONReader returns a NDJSONReader reading from r.
+func NewNDJSONReader(r io.Reader) *NDJSONReader {
+	return &NDJSONReader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next row. Lines that are not a JSON object are returned as row without values
+// and with the reason as Err.
+func (r *NDJSONReader) Read() (*Row, error) {
+	for {
+		data, err := r.r.ReadBytes('\n')
+		if err != nil && (err != io.EOF || len(data) == 0) {
+			return nil, err
+		}
+		r.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		columns, values, err := decodeObject(data)
+		if err != nil {
+			return &Row{Line: r.line, Err: fmt.Errorf("line %d: %w", r.line, err)}, nil
+		}
+		return &Row{Line: r.line, Columns: columns, Values: values}, nil
+	}
+}
+
+// decodeObject decodes a JSON object and returns its keys in order and its values, keeping numbers as json.Number.
+func decodeObject(data []byte) ([]string, map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("row is not a JSON object: %s", data)
+	}
+
+	var columns []string
+	values := map[string]interface{}{}
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		column := token.(string)
+
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := values[column]; !ok {
+			columns = append(columns, column)
+		}
+		values[column] = value
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	return columns, values, nil
+}
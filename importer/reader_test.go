@@ -0,0 +1,102 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCSVReader(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("\ufeffName,Age\nJohn,21\n\"Jane\nDoe\",\n\nRichard,30\n"))
+
+	row, err := r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, &Row{Line: 2, Columns: []string{"Name", "Age"}, Values: map[string]interface{}{"Name": "John", "Age": "21"}}, row)
+
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, &Row{Line: 3, Columns: []string{"Name", "Age"}, Values: map[string]interface{}{"Name": "Jane\nDoe", "Age": ""}}, row)
+
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 6, row.Line)
+	assert.Equal(t, "Richard", row.Values["Name"])
+
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+
+	// rows with the wrong number of values are returned with the reason
+	r = NewCSVReader(strings.NewReader("Name,Age\nJohn\nJane,28,x\nRichard,30\n"))
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "John"}, row.Values)
+	assert.True(t, errors.Is(row.Err, csv.ErrFieldCount))
+	assert.EqualError(t, row.Err, "wrong number of fields: 1 values for 2 columns")
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"Name": "Jane", "Age": "28"}, row.Values)
+	assert.True(t, errors.Is(row.Err, csv.ErrFieldCount))
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Nil(t, row.Err)
+
+	// rows that cannot be parsed are returned with the reason and reading continues
+	r = NewCSVReader(strings.NewReader("Name,Age\nJo\"hn,21\nJane,28\n"))
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, row.Line)
+	assert.Equal(t, []string{"Name", "Age"}, row.Columns)
+	assert.Empty(t, row.Values)
+	var parseErr *csv.ParseError
+	assert.True(t, errors.As(row.Err, &parseErr))
+	assert.True(t, errors.Is(row.Err, csv.ErrBareQuote))
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Nil(t, row.Err)
+	assert.Equal(t, "Jane", row.Values["Name"])
+
+	_, err = NewCSVReader(strings.NewReader("")).Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestNDJSONReader(t *testing.T) {
+	r := NewNDJSONReader(strings.NewReader(`{"Name": "John", "Age": 21, "Tasks": [1, 2]}` + "\n\n" + `{"Name": "Jane"}`))
+
+	row, err := r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, &Row{Line: 1, Columns: []string{"Name", "Age", "Tasks"}, Values: map[string]interface{}{
+		"Name": "John", "Age": json.Number("21"), "Tasks": []interface{}{json.Number("1"), json.Number("2")},
+	}}, row)
+
+	// empty lines are skipped and the last line needs no newline
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, &Row{Line: 3, Columns: []string{"Name"}, Values: map[string]interface{}{"Name": "Jane"}}, row)
+
+	_, err = r.Read()
+	assert.Equal(t, io.EOF, err)
+
+	// lines that are not a JSON object are returned with the reason and reading continues
+	r = NewNDJSONReader(strings.NewReader("[1]\nnot json\n{\"Name\": \"John\"}\n"))
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, row.Line)
+	assert.EqualError(t, row.Err, "line 1: row is not a JSON object: [1]")
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, row.Line)
+	assert.Nil(t, row.Values)
+	assert.Error(t, row.Err)
+	row, err = r.Read()
+	assert.Nil(t, err)
+	assert.Nil(t, row.Err)
+	assert.Equal(t, "John", row.Values["Name"])
+
+	row, err = NewNDJSONReader(strings.NewReader(`{"Name": `)).Read()
+	assert.Nil(t, err)
+	assert.Error(t, row.Err)
+}
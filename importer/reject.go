@@ -0,0 +1,115 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+)
+
+// ErrorColumn is the column rejected rows carry the reason of their rejection in.
+const ErrorColumn = "import_error"
+
+// RejectWriter writes the rows rejected by an import, so they can be corrected and imported again.
+type RejectWriter interface {
+	// Reject writes the row together with the reason of its rejection
+	Reject(row *Row, reason error) error
+
+	// Flush writes buffered rows to the underlying io.Writer
+	Flush() error
+}
+
+// CSVRejectWriter writes rejected rows as CSV with the columns of the first rejected row and the ErrorColumn.
+type CSVRejectWriter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+// NewCSVRejectWriter returns a CSVRejectWriter writing to w.
+func NewCSVRejectWriter(w io.Writer) *CSVRejectWriter {
+	return &CSVRejectWriter{w: csv.NewWriter(w)}
+}
+
+// Reject writes the row, preceded by the header row for the first rejected row.
+func (w *CSVRejectWriter) Reject(row *Row, reason error) error {
+	if w.columns == nil {
+		w.columns = row.Columns
+		if err := w.w.Write(append(append([]string(nil), w.columns...), ErrorColumn)); err != nil {
+			return err
+		}
+	}
+
+	record := make([]string, 0, len(w.columns)+1)
+	for _, column := range w.columns {
+		switch value := row.Values[column].(type) {
+		case nil:
+			record = append(record, "")
+		case string:
+			record = append(record, value)
+		default:
+			data, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			record = append(record, string(data))
+		}
+	}
+	return w.w.Write(append(record, reason.Error()))
+}
+
+// Flush writes buffered rows to the underlying io.Writer.
+func (w *CSVRejectWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// NDJSONRejectWriter writes rejected rows as newline-delimited JSON with the ErrorColumn as additional key.
+type NDJSONRejectWriter struct {
+	w *bufio.Writer
+}
+
+// NewNDJSONRejectWriter returns a NDJSONRejectWriter writing to w.
+func NewNDJSONRejectWriter(w io.Writer) *NDJSONRejectWriter {
+	return &NDJSONRejectWriter{w: bufio.NewWriter(w)}
+}
+
+// Reject writes the row as a line of JSON keeping the order of its columns.
+func (w *NDJSONRejectWriter) Reject(row *Row, reason error) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for _, column := range row.Columns {
+		if err := writeMember(&line, column, row.Values[column]); err != nil {
+			return err
+		}
+		line.WriteByte(',')
+	}
+	if err := writeMember(&line, ErrorColumn, reason.Error()); err != nil {
+		return err
+	}
+	line.WriteString("}\n")
+
+	_, err := w.w.Write(line.Bytes())
+	return err
+}
+
+// Flush writes buffered rows to the underlying io.Writer.
+func (w *NDJSONRejectWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// writeMember appends the key and the value of a JSON object member to buf.
+func writeMember(buf *bytes.Buffer, key string, value interface{}) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	buf.WriteByte(':')
+
+	if data, err = json.Marshal(value); err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
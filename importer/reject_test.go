@@ -0,0 +1,33 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCSVRejectWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVRejectWriter(&buf)
+
+	columns := []string{"Name", "Age"}
+	assert.Nil(t, w.Reject(&Row{Line: 2, Columns: columns, Values: map[string]interface{}{"Name": "John", "Age": "x"}}, errors.New("invalid number")))
+	assert.Nil(t, w.Reject(&Row{Line: 3, Columns: columns, Values: map[string]interface{}{"Name": "Jane, Doe", "Age": json.Number("5")}}, errors.New("failed")))
+	assert.Nil(t, w.Reject(&Row{Line: 4, Columns: columns, Values: map[string]interface{}{"Age": []interface{}{"a"}}}, errors.New("failed")))
+	assert.Nil(t, w.Flush())
+
+	assert.Equal(t, "Name,Age,import_error\nJohn,x,invalid number\n\"Jane, Doe\",5,failed\n,\"[\"\"a\"\"]\",failed\n", buf.String())
+}
+
+func TestNDJSONRejectWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewNDJSONRejectWriter(&buf)
+
+	assert.Nil(t, w.Reject(&Row{Line: 1, Columns: []string{"Name", "Age"}, Values: map[string]interface{}{"Name": "John", "Age": json.Number("21")}}, errors.New("failed")))
+	assert.Nil(t, w.Reject(&Row{Line: 2}, errors.New("empty")))
+	assert.Nil(t, w.Flush())
+
+	assert.Equal(t, `{"Name":"John","Age":21,"import_error":"failed"}`+"\n"+`{"import_error":"empty"}`+"\n", buf.String())
+}
@@ -0,0 +1,237 @@
+package adalo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go/internal/rawrecord"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// FieldStats summarizes the values of a field observed by InferSchema.
+type FieldStats struct {
+	// Values is the number of records with a value in the field
+	Values int `json:"values" yaml:"values"`
+
+	// Nulls is the number of records where the field was null, empty or missing
+	Nulls int `json:"nulls" yaml:"nulls"`
+
+	// Min and Max are the smallest and largest observed number, date or date & time
+	Min string `json:"min,omitempty" yaml:"min,omitempty"`
+	Max string `json:"max,omitempty" yaml:"max,omitempty"`
+
+	// MinLength and MaxLength are the shortest and longest observed text
+	// or the lowest and highest number of related records
+	MinLength *int `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength *int `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+}
+
+// datePattern matches the values of date fields.
+var datePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// InferSchema scans all records of the collection and infers the type of each field from the
+// observed values. Fields that were empty in at least one record are marked as nullable.
+// The returned schema has no Name, which must be set before it is used with adalo-gen.
+func InferSchema(ctx context.Context, c *Collection) (*CollectionSchema, error) {
+	var order []string
+	observed := map[string]*fieldObservation{}
+
+	count := 0
+	it := c.WithoutIncludes().Iterate(ctx, ListOptions{})
+	for it.Next() {
+		count++
+		keys, err := rawrecord.Keys(it.Record())
+		if err != nil {
+			return nil, err
+		}
+
+		var record map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(it.Record()))
+		decoder.UseNumber()
+		if err := decoder.Decode(&record); err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			// the fields Adalo sets for every record are not part of a CollectionSchema
+			if rawrecord.IsSystemField(key) {
+				continue
+			}
+			if observed[key] == nil {
+				// records seen before did not contain the field
+				observed[key] = &fieldObservation{types: map[FieldType]bool{}, stats: FieldStats{Nulls: count - 1}}
+				order = append(order, key)
+			}
+			observed[key].observe(record[key])
+		}
+		for key, o := range observed {
+			if _, ok := record[key]; !ok {
+				o.stats.Nulls++
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	schema := &CollectionSchema{ID: c.ID, Fields: []FieldSchema{}}
+	for _, key := range order {
+		o := observed[key]
+		stats := o.stats
+		schema.Fields = append(schema.Fields, FieldSchema{
+			Name:     key,
+			Type:     o.fieldType(),
+			Nullable: stats.Nulls > 0,
+			Observed: &stats,
+		})
+	}
+	return schema, nil
+}
+
+// fieldObservation collects the values observed for a field.
+type fieldObservation struct {
+	types map[FieldType]bool
+	stats FieldStats
+
+	// minNumber and maxNumber are the smallest and largest observed number
+	minNumber, maxNumber *float64
+}
+
+// observe adds the value of the field in a record to the observation.
+func (o *fieldObservation) observe(value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		o.stats.Nulls++
+		return
+	case bool:
+		o.types[FieldBoolean] = true
+	case json.Number:
+		o.types[FieldNumber] = true
+		if f, err := v.Float64(); err == nil {
+			o.observeNumber(f)
+		}
+	case string:
+		if v == "" {
+			o.stats.Nulls++
+			return
+		}
+		switch {
+		case datePattern.MatchString(v):
+			o.types[FieldDate] = true
+			o.observeRange(v)
+		case isDateTime(v):
+			o.types[FieldDateTime] = true
+			o.observeRange(v)
+		default:
+			o.types[FieldText] = true
+			o.observeLength(len([]rune(v)))
+		}
+	case []interface{}:
+		if isIDs(v) {
+			o.types[FieldRelationship] = true
+		} else {
+			o.types[FieldJSON] = true
+		}
+		o.observeLength(len(v))
+	case map[string]interface{}:
+		o.types[objectType(v)] = true
+	default:
+		o.types[FieldText] = true
+	}
+	o.stats.Values++
+}
+
+// observeNumber updates the observed range of numbers.
+func (o *fieldObservation) observeNumber(f float64) {
+	if o.minNumber == nil || f < *o.minNumber {
+		o.minNumber = &f
+		o.stats.Min = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	if o.maxNumber == nil || f > *o.maxNumber {
+		o.maxNumber = &f
+		o.stats.Max = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+}
+
+// observeRange updates the observed range of dates, which can be compared lexically.
+func (o *fieldObservation) observeRange(v string) {
+	if o.stats.Min == "" || v < o.stats.Min {
+		o.stats.Min = v
+	}
+	if o.stats.Max == "" || v > o.stats.Max {
+		o.stats.Max = v
+	}
+}
+
+// observeLength updates the observed range of lengths.
+func (o *fieldObservation) observeLength(n int) {
+	if o.stats.MinLength == nil || n < *o.stats.MinLength {
+		o.stats.MinLength = &n
+	}
+	if o.stats.MaxLength == nil || n > *o.stats.MaxLength {
+		o.stats.MaxLength = &n
+	}
+}
+
+// fieldType resolves the observed types to a single field type.
+// Fields without any observed value as well as fields with conflicting values are text fields.
+func (o *fieldObservation) fieldType() FieldType {
+	if len(o.types) == 1 {
+		for t := range o.types {
+			return t
+		}
+	}
+	if len(o.types) == 2 && o.types[FieldDate] && o.types[FieldDateTime] {
+		return FieldDateTime
+	}
+	if len(o.types) == 2 && o.types[FieldImage] && o.types[FieldFile] {
+		// images without dimensions look like files
+		return FieldImage
+	}
+	if len(o.types) == 2 && o.types[FieldRelationship] && o.types[FieldJSON] {
+		// empty arrays look like relationships
+		return FieldJSON
+	}
+	return FieldText
+}
+
+// objectType infers the type of a field whose value is a JSON object.
+func objectType(v map[string]interface{}) FieldType {
+	if _, ok := v["coordinates"]; ok {
+		return FieldLocation
+	}
+	if _, ok := v["fullAddress"]; ok {
+		return FieldLocation
+	}
+	if _, ok := v["url"]; ok {
+		_, hasWidth := v["width"]
+		_, hasHeight := v["height"]
+		if hasWidth || hasHeight {
+			return FieldImage
+		}
+		return FieldFile
+	}
+	return FieldText
+}
+
+// isIDs reports whether all elements of the array are record IDs, i.e. positive integers.
+func isIDs(v []interface{}) bool {
+	for _, element := range v {
+		n, ok := element.(json.Number)
+		if !ok {
+			return false
+		}
+		if id, err := n.Int64(); err != nil || id <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isDateTime reports whether v is formatted as the value of a date & time field.
+func isDateTime(v string) bool {
+	_, err := time.Parse(time.RFC3339, v)
+	return err == nil
+}
@@ -0,0 +1,72 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInferSchema(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("persons",
+		json.RawMessage(`{"id": 1, "Name": "John", "Age": 21, "Admin": true, "Birthday": "2000-01-31", "Last Login": "2021-03-01T10:00:00.000Z",
+			"Avatar": {"url": "https://cdn/a.png", "width": 10, "height": 10}, "CV": {"url": "https://cdn/cv.pdf", "size": 100},
+			"Home": {"fullAddress": "Main St 1", "coordinates": {"latitude": 1, "longitude": 2}}, "Tasks": [1, 2, 3],
+			"Tags": ["a", "b"], "Links": [{"id": 1}], "Members": [],
+			"created_at": "2021-01-01T10:00:00.000Z", "updated_at": "2021-01-01T10:00:00.000Z"}`),
+		json.RawMessage(`{"id": 2, "Name": "Jane Doe", "Age": 35.5, "Admin": false, "Birthday": null, "Last Login": "2021-02-01T10:00:00.000Z",
+			"Avatar": null, "CV": null, "Home": null, "Tasks": [], "Nickname": "JD", "Tags": [], "Members": [5],
+			"created_at": "2021-01-01T10:00:00.000Z", "updated_at": "2021-01-01T10:00:00.000Z"}`),
+		json.RawMessage(`{"id": 3, "Name": "", "Age": 7, "Admin": true, "Birthday": "1990-05-05", "Last Login": "2021-04-01T10:00:00.000Z",
+			"Avatar": null, "CV": null, "Home": null, "Tasks": [4],
+			"created_at": "2021-01-01T10:00:00.000Z", "updated_at": "2021-01-01T10:00:00.000Z"}`),
+	)
+
+	collection := newTestClient(srv).Collection("persons")
+	schema, err := InferSchema(context.Background(), collection)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "persons", schema.ID)
+	fields := map[string]FieldSchema{}
+	var names []string
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+		names = append(names, field.Name)
+	}
+	// the fake server returns the fields ordered by name, fields missing in the first record follow
+	assert.Equal(t, []string{"Admin", "Age", "Avatar", "Birthday", "CV", "Home", "Last Login", "Links", "Members", "Name", "Tags", "Tasks", "Nickname"}, names)
+
+	assert.Equal(t, FieldText, fields["Name"].Type)
+	assert.True(t, fields["Name"].Nullable)
+	assert.Equal(t, 4, *fields["Name"].Observed.MinLength)
+	assert.Equal(t, 8, *fields["Name"].Observed.MaxLength)
+
+	assert.Equal(t, FieldNumber, fields["Age"].Type)
+	assert.False(t, fields["Age"].Nullable)
+	assert.Equal(t, "7", fields["Age"].Observed.Min)
+	assert.Equal(t, "35.5", fields["Age"].Observed.Max)
+
+	assert.Equal(t, FieldBoolean, fields["Admin"].Type)
+	assert.Equal(t, FieldDate, fields["Birthday"].Type)
+	assert.Equal(t, "1990-05-05", fields["Birthday"].Observed.Min)
+	assert.Equal(t, 1, fields["Birthday"].Observed.Nulls)
+	assert.Equal(t, FieldDateTime, fields["Last Login"].Type)
+	assert.Equal(t, FieldImage, fields["Avatar"].Type)
+	assert.Equal(t, FieldFile, fields["CV"].Type)
+	assert.Equal(t, FieldLocation, fields["Home"].Type)
+	assert.Equal(t, FieldRelationship, fields["Tasks"].Type)
+	assert.Equal(t, 3, *fields["Tasks"].Observed.MaxLength)
+	assert.Equal(t, FieldJSON, fields["Tags"].Type)
+	assert.Equal(t, FieldJSON, fields["Links"].Type)
+	assert.Equal(t, FieldRelationship, fields["Members"].Type)
+
+	assert.Equal(t, FieldText, fields["Nickname"].Type)
+	assert.True(t, fields["Nickname"].Nullable)
+	assert.Equal(t, 2, fields["Nickname"].Observed.Nulls)
+	assert.Equal(t, 1, fields["Nickname"].Observed.Values)
+}
@@ -0,0 +1,44 @@
+// Package rawrecord inspects raw Adalo records for the packages of this module.
+package rawrecord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// systemFields are the fields Adalo sets for every record.
+var systemFields = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// IsSystemField reports whether the field is set by Adalo for every record, i.e. id, created_at or updated_at.
+func IsSystemField(field string) bool {
+	return systemFields[field]
+}
+
+// Keys returns the keys of the raw record in their order in the record.
+func Keys(record json.RawMessage) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(record))
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if token != json.Delim('{') {
+		return nil, fmt.Errorf("record is not a JSON object: %s", record)
+	}
+
+	var keys []string
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, token.(string))
+
+		// skip the value
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
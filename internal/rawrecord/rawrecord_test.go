@@ -0,0 +1,23 @@
+package rawrecord
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsSystemField(t *testing.T) {
+	assert.True(t, IsSystemField("id"))
+	assert.True(t, IsSystemField("created_at"))
+	assert.True(t, IsSystemField("updated_at"))
+	assert.False(t, IsSystemField("Name"))
+}
+
+func TestKeys(t *testing.T) {
+	keys, err := Keys(json.RawMessage(`{"b": {"x": 1}, "a": [1, 2], "c": null}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "a", "c"}, keys)
+
+	_, err = Keys(json.RawMessage(`[1, 2]`))
+	assert.Error(t, err)
+}
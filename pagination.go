@@ -0,0 +1,239 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// MaxPageSize is the maximum number of records the Adalo API returns per page.
+const MaxPageSize = 100
+
+// ListOptions selects the page of records returned by Collection.List.
+type ListOptions struct {
+	// Offset is the number of records to skip
+	Offset int
+
+	// Limit is the maximum number of records in the page, it defaults to and is capped at MaxPageSize
+	Limit int
+
+	// Filter restricts the page to records matching the filter, it defaults to the filter set with Collection.Where
+	Filter *Filter
+}
+
+// Filter restricts listed records to those whose field equals the value.
+// It is applied by the Adalo API before records are paginated.
+type Filter struct {
+	// Field is the name of the field in the Adalo collection, e.g. "Email"
+	Field string
+
+	// Value is the value the field must equal
+	Value string
+}
+
+// limit returns the page size to request.
+func (o ListOptions) limit() int {
+	if o.Limit <= 0 || o.Limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return o.Limit
+}
+
+// query returns the query parameters sent to the API.
+func (o ListOptions) query() url.Values {
+	query := url.Values{}
+	query.Set("offset", strconv.Itoa(o.Offset))
+	query.Set("limit", strconv.Itoa(o.limit()))
+	if o.Filter != nil {
+		query.Set("filterKey", o.Filter.Field)
+		query.Set("filterValue", o.Filter.Value)
+	}
+	return query
+}
+
+// Page is a page of records returned by Collection.List.
+type Page struct {
+	// Records contains the raw records of the page
+	Records []json.RawMessage
+
+	// Offset is the number of records skipped before this page
+	Offset int
+
+	// Limit is the page size the page was requested with
+	Limit int
+
+	// filter is the filter the page was requested with
+	filter *Filter
+
+	// relationIDs holds the IDs of the dangling relations of the records loaded with Include, see loadIncludes
+	relationIDs []relationIDs
+}
+
+// listResponse is a representation of the response returned by the Adalo API when listing records.
+type listResponse struct {
+	Records []json.RawMessage `json:"records"`
+}
+
+// Bind binds the records of the page to the passed result variable, which should point to a slice.
+func (p *Page) Bind(result interface{}) error {
+	records := p.Records
+	if records == nil {
+		records = []json.RawMessage{}
+	}
+	body, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	if err := decodeResponse(body, result); err != nil {
+		return err
+	}
+	bindRelationIDs(result, p.relationIDs)
+	return nil
+}
+
+// HasMore reports whether further records may follow this page.
+func (p *Page) HasMore() bool {
+	return len(p.Records) > 0 && len(p.Records) >= p.Limit
+}
+
+// Next returns the ListOptions to request the page following this page.
+func (p *Page) Next() ListOptions {
+	return ListOptions{Offset: p.Offset + len(p.Records), Limit: p.Limit, Filter: p.filter}
+}
+
+// List fetches a single page of records from the collection. The relationship fields keep the IDs
+// of the related records, which are only loaded by All, Iterate and Get, see Include.
+func (c *Collection) List(ctx context.Context, opts ListOptions) (*Page, error) {
+	if opts.Filter == nil {
+		opts.Filter = c.filter
+	}
+
+	query := opts.query().Encode()
+	body, err := c.get(ctx, c.listCacheKey(query), c.collectionAPIBaseURL()+"?"+query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response listResponse
+	if err := decodeResponse(body, &response); err != nil {
+		return nil, err
+	}
+
+	return &Page{
+		Records: response.Records,
+		Offset:  opts.Offset,
+		Limit:   opts.limit(),
+		filter:  opts.Filter,
+	}, nil
+}
+
+// Where returns a copy of the collection whose All, List and Iterate only return records
+// whose field equals the value. The filter is applied by the Adalo API.
+//
+//	var result []User
+//	err := userCollection.Where("Email", "john.doe@gmail.com").All(&result)
+func (c *Collection) Where(field, value string) *Collection {
+	filtered := *c
+	filtered.filter = &Filter{Field: field, Value: value}
+	return &filtered
+}
+
+// Iterator walks through the records of a collection page by page.
+// Pages are fetched lazily when the records of the previous page are consumed.
+//
+//	it := collection.Iterate(ctx, adalo.ListOptions{})
+//	for it.Next() {
+//		var person Person
+//		if err := it.Scan(&person); err != nil {
+//			return err
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator struct {
+	ctx        context.Context
+	collection *Collection
+
+	// opts selects the next page to fetch
+	opts ListOptions
+
+	// page is the current page, index the position of the current record in it
+	page  *Page
+	index int
+
+	err  error
+	done bool
+}
+
+// Iterate returns an Iterator over the records of the collection, starting at opts.Offset
+// and fetching pages with opts.Limit records.
+func (c *Collection) Iterate(ctx context.Context, opts ListOptions) *Iterator {
+	return &Iterator{ctx: ctx, collection: c, opts: opts, index: -1}
+}
+
+// Next advances the iterator to the next record, which is then available through Record and Scan.
+// It returns false when all records were consumed or an error occurred.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.page != nil && it.index+1 < len(it.page.Records) {
+		it.index++
+		return true
+	}
+
+	if it.done || (it.page != nil && !it.page.HasMore()) {
+		it.done = true
+		return false
+	}
+
+	page, err := it.collection.List(it.ctx, it.opts)
+	if err == nil {
+		page.relationIDs, err = it.collection.loadIncludes(it.ctx, page.Records)
+	}
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page, it.index, it.opts = page, 0, page.Next()
+
+	if len(page.Records) == 0 {
+		it.done = true
+		return false
+	}
+	return true
+}
+
+// Record returns the raw current record.
+func (it *Iterator) Record() json.RawMessage {
+	if it.page == nil || it.index < 0 || it.index >= len(it.page.Records) {
+		return nil
+	}
+	return it.page.Records[it.index]
+}
+
+// Scan binds the current record to the passed result variable.
+func (it *Iterator) Scan(result interface{}) error {
+	if err := decodeResponse(it.Record(), result); err != nil {
+		return err
+	}
+	it.relationIDs().bind(reflect.ValueOf(result))
+	return nil
+}
+
+// relationIDs returns the IDs of the dangling relations of the current record, if any.
+func (it *Iterator) relationIDs() relationIDs {
+	if it.Record() == nil || it.page.relationIDs == nil {
+		return nil
+	}
+	return it.page.relationIDs[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
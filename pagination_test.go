@@ -0,0 +1,151 @@
+package adalo
+
+import (
+	"context"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+// pagingPersons returns the given number of records for the persons collection in the pagination tests.
+func pagingPersons(count int) []interface{} {
+	records := make([]interface{}, count)
+	for i := range records {
+		records[i] = personInput{Name: "Person " + strconv.Itoa(i+1), Age: (i + 1) % 10}
+	}
+	return records
+}
+
+// queries returns the encoded queries of the requests received by the server.
+func queries(srv *adalotest.Server) []string {
+	var queries []string
+	for _, r := range srv.Requests() {
+		queries = append(queries, r.Query.Encode())
+	}
+	return queries
+}
+
+func TestCollection_List(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", pagingPersons(150)...)
+	defer srv.Close()
+	collection := newTestClient(srv).Collection("persons")
+
+	t.Run("first page", func(t *testing.T) {
+		page, err := collection.List(context.Background(), ListOptions{Limit: 20})
+		assert.Nil(t, err)
+		assert.Len(t, page.Records, 20)
+		assert.True(t, page.HasMore())
+		assert.Equal(t, ListOptions{Offset: 20, Limit: 20}, page.Next())
+
+		var result []person
+		assert.Nil(t, page.Bind(&result))
+		assert.Equal(t, 1, result[0].ID)
+		assert.Equal(t, "Person 20", result[19].Name)
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		page, err := collection.List(context.Background(), ListOptions{Offset: 100, Limit: 1000})
+		assert.Nil(t, err)
+		assert.Len(t, page.Records, 50)
+		assert.Equal(t, MaxPageSize, page.Limit)
+		assert.False(t, page.HasMore())
+		requests := queries(srv)
+		assert.Equal(t, "limit=100&offset=100", requests[len(requests)-1])
+	})
+}
+
+func TestCollection_Iterate(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", pagingPersons(25)...)
+	defer srv.Close()
+	collection := newTestClient(srv).Collection("persons")
+
+	it := collection.Iterate(context.Background(), ListOptions{Offset: 5, Limit: 10})
+	var ids []int
+	for it.Next() {
+		var p person
+		assert.Nil(t, it.Scan(&p))
+		ids = append(ids, p.ID)
+	}
+	assert.Nil(t, it.Err())
+	assert.Len(t, ids, 20)
+	assert.Equal(t, 6, ids[0])
+	assert.Equal(t, 25, ids[19])
+	assert.Equal(t, []string{"limit=10&offset=5", "limit=10&offset=15", "limit=10&offset=25"}, queries(srv))
+	assert.False(t, it.Next())
+}
+
+func TestCollection_All_pages(t *testing.T) {
+	t.Run("fetches every page", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", pagingPersons(230)...)
+		defer srv.Close()
+		collection := newTestClient(srv).Collection("persons")
+
+		var result []person
+		err := collection.All(&result)
+		assert.Nil(t, err)
+		assert.Len(t, result, 230)
+		assert.Equal(t, 230, result[229].ID)
+		assert.Len(t, queries(srv), 3)
+	})
+
+	t.Run("empty collection", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", pagingPersons(0)...)
+		defer srv.Close()
+		collection := newTestClient(srv).Collection("persons")
+
+		var result []person
+		err := collection.All(&result)
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+		assert.Len(t, result, 0)
+	})
+}
+
+func TestCollection_Where(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", pagingPersons(250)...)
+	defer srv.Close()
+	collection := newTestClient(srv).Collection("persons")
+
+	t.Run("with all", func(t *testing.T) {
+		var result []person
+		err := collection.Where("Age", "7").All(&result)
+		assert.Nil(t, err)
+		assert.Len(t, result, 25)
+		for _, p := range result {
+			assert.Equal(t, 7, p.Age)
+		}
+		requests := queries(srv)
+		assert.Equal(t, "filterKey=Age&filterValue=7&limit=100&offset=0", requests[len(requests)-1])
+	})
+
+	t.Run("composes with pagination", func(t *testing.T) {
+		srv.ResetRequests()
+		it := collection.Where("Age", "3").Iterate(context.Background(), ListOptions{Offset: 5, Limit: 10})
+		var count int
+		for it.Next() {
+			count++
+		}
+		assert.Nil(t, it.Err())
+		assert.Equal(t, 20, count)
+		assert.Equal(t, []string{
+			"filterKey=Age&filterValue=3&limit=10&offset=5",
+			"filterKey=Age&filterValue=3&limit=10&offset=15",
+			"filterKey=Age&filterValue=3&limit=10&offset=25",
+		}, queries(srv))
+	})
+
+	t.Run("with list options", func(t *testing.T) {
+		page, err := collection.List(context.Background(), ListOptions{Filter: &Filter{Field: "Name", Value: "Person 42"}})
+		assert.Nil(t, err)
+		assert.Len(t, page.Records, 1)
+		assert.Equal(t, &Filter{Field: "Name", Value: "Person 42"}, page.Next().Filter)
+	})
+
+	t.Run("does not change the collection", func(t *testing.T) {
+		collection.Where("Age", "1")
+		page, err := collection.List(context.Background(), ListOptions{})
+		assert.Nil(t, err)
+		assert.Len(t, page.Records, 100)
+	})
+}
@@ -0,0 +1,126 @@
+package adalo
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Patch is an input for Update that only contains the fields that were explicitly set or cleared,
+// so all other fields of the record are left untouched.
+//
+//	patch := adalo.NewPatch().Set("Age", 22).Clear("Nickname")
+//	err := personCollection.Update(1, patch, &person)
+type Patch struct {
+	fields map[string]interface{}
+}
+
+// NewPatch initializes an empty Patch.
+func NewPatch() *Patch {
+	return &Patch{fields: map[string]interface{}{}}
+}
+
+// Set sets the field to the value.
+func (p *Patch) Set(field string, value interface{}) *Patch {
+	if p.fields == nil {
+		p.fields = map[string]interface{}{}
+	}
+	p.fields[field] = value
+	return p
+}
+
+// Clear sets the field to null, which removes its value in Adalo.
+func (p *Patch) Clear(field string) *Patch {
+	return p.Set(field, nil)
+}
+
+// Fields returns the sorted names of the fields that were set or cleared.
+func (p *Patch) Fields() []string {
+	fields := make([]string, 0, len(p.fields))
+	for field := range p.fields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MarshalJSON encodes the set and cleared fields as JSON object.
+// It has a value receiver, so a Patch passed by value is encoded the same way as a *Patch.
+func (p Patch) MarshalJSON() ([]byte, error) {
+	if p.fields == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(p.fields)
+}
+
+// optionalState tells whether an Optional is absent, null or holds a value.
+type optionalState uint8
+
+// list of states of an Optional
+const (
+	optionalAbsent optionalState = iota
+	optionalNull
+	optionalValue
+)
+
+// Optional is a field of an input struct that distinguishes an omitted field, an explicit null
+// and a value. Its zero value is absent and is left out of the JSON encoding when the field
+// is tagged with omitzero, so Update does not touch the field.
+//
+//	type PersonInput struct {
+//		Name     adalo.Optional[string] `json:"Name,omitzero"`
+//		Nickname adalo.Optional[string] `json:"Nickname,omitzero"`
+//	}
+//
+//	input := PersonInput{Name: adalo.Some("John"), Nickname: adalo.Null[string]()}
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// Some returns an Optional holding the value.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, state: optionalValue}
+}
+
+// Null returns an Optional that is encoded as null, which clears the field in Adalo.
+func Null[T any]() Optional[T] {
+	return Optional[T]{state: optionalNull}
+}
+
+// IsZero reports whether the Optional is absent, which makes omitzero leave it out.
+func (o Optional[T]) IsZero() bool {
+	return o.state == optionalAbsent
+}
+
+// IsNull reports whether the Optional is an explicit null.
+func (o Optional[T]) IsNull() bool {
+	return o.state == optionalNull
+}
+
+// Get returns the value and whether the Optional holds a value.
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalValue
+}
+
+// MarshalJSON encodes the value, or null if the Optional holds no value.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalValue {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// UnmarshalJSON decodes null as explicit null and everything else as value.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Null[T]()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
@@ -0,0 +1,97 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPatch(t *testing.T) {
+	t.Run("marshals set and cleared fields only", func(t *testing.T) {
+		patch := NewPatch().Set("Age", 22).Clear("Nickname").Set("Date of Birth", "2000-01-31")
+		data, err := json.Marshal(patch)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"Age": 22, "Nickname": null, "Date of Birth": "2000-01-31"}`, string(data))
+		assert.Equal(t, []string{"Age", "Date of Birth", "Nickname"}, patch.Fields())
+	})
+
+	t.Run("zero value", func(t *testing.T) {
+		var patch Patch
+		data, err := json.Marshal(&patch)
+		assert.Nil(t, err)
+		assert.Equal(t, `{}`, string(data))
+
+		patch.Set("Name", "John")
+		assert.Equal(t, []string{"Name"}, patch.Fields())
+	})
+
+	t.Run("is sent by update", func(t *testing.T) {
+		var body []byte
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ = ioutil.ReadAll(r.Body)
+			_, _ = w.Write([]byte(`{"id": 1}`))
+		}))
+		defer srv.Close()
+
+		collection := NewClient("key", "app", WithCollectionsBaseURL(srv.URL)).Collection("persons")
+		assert.Nil(t, collection.Update(1, NewPatch().Set("Age", 22).Clear("Name"), nil))
+		assert.JSONEq(t, `{"Age": 22, "Name": null}`, string(body))
+
+		patch := NewPatch().Set("Age", 23)
+		assert.Nil(t, collection.UpdateContext(context.Background(), 1, *patch, nil))
+		assert.JSONEq(t, `{"Age": 23}`, string(body))
+
+		typed := Typed[person, Patch](collection)
+		_, err := typed.Update(context.Background(), 1, *NewPatch().Clear("Nickname"))
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"Nickname": null}`, string(body))
+	})
+}
+
+func TestOptional(t *testing.T) {
+	type input struct {
+		Name     Optional[string] `json:"Name,omitzero"`
+		Nickname Optional[string] `json:"Nickname,omitzero"`
+		Age      Optional[int]    `json:"Age,omitzero"`
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		data, err := json.Marshal(input{Name: Some("John"), Nickname: Null[string]()})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"Name": "John", "Nickname": null}`, string(data))
+
+		data, err = json.Marshal(input{Age: Some(0)})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"Age": 0}`, string(data))
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var result input
+		err := json.Unmarshal([]byte(`{"Name": "John", "Nickname": null}`), &result)
+		assert.Nil(t, err)
+
+		name, ok := result.Name.Get()
+		assert.True(t, ok)
+		assert.Equal(t, "John", name)
+		assert.True(t, result.Nickname.IsNull())
+		assert.True(t, result.Age.IsZero())
+
+		assert.Error(t, json.Unmarshal([]byte(`{"Age": "old"}`), &result))
+	})
+
+	t.Run("states", func(t *testing.T) {
+		var absent Optional[string]
+		assert.True(t, absent.IsZero())
+		assert.False(t, absent.IsNull())
+		_, ok := absent.Get()
+		assert.False(t, ok)
+
+		assert.False(t, Null[string]().IsZero())
+		assert.False(t, Some("").IsZero())
+		assert.False(t, Some("").IsNull())
+	})
+}
@@ -1,25 +1,19 @@
 package adalo
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"io/ioutil"
 	"net/http"
-	"strings"
 )
 
-// pushNotificationApiURL is the base url for push notification api calls on Adalo.
-const pushNotificationApiURL = "https://api.adalo.com/notifications"
-
 // ErrorUserNotFound is returned by the API when the recipient email does not
 // exist in the users collection in the Adalo app.
 var ErrorUserNotFound = errors.New("user not found")
 
 // PushNotificationInput is a representation of the input expected by the Adalo API.
 type PushNotificationInput struct {
-	// (optional) if not specified, global AppID is being taken
+	// (optional) if not specified, the AppID of the client is being taken
 	AppID *string `json:"appId"`
 
 	// Audience of this push notification
@@ -45,50 +39,52 @@ type PushNotificationContentInput struct {
 	Body string `json:"bodyText"`
 }
 
-// SendPushNotification requests the Adalo API to send a push notification.
+// pushNotificationResponse is a representation of the response returned by the Adalo API
+// when a push notification was sent.
+type pushNotificationResponse struct {
+	// Successful is the number of sent push notifications
+	Successful *int `json:"successful"`
+}
+
+// SendPushNotification requests the Adalo API to send a push notification using the global ApiKey and AppID.
 // It returns the number of sent push notifications and any write error encountered.
 func SendPushNotification(input *PushNotificationInput) (int, error) {
-	if input.AppID == nil {
-		// using global app id
-		input.AppID = &AppID
-	}
-
-	inputBytes, err := json.Marshal(input)
-	if err != nil {
-		return 0, err
-	}
-	payload := bytes.NewReader(inputBytes)
+	return defaultClient.SendPushNotificationContext(context.Background(), input)
+}
 
-	client := &http.Client{}
-	req, err := http.NewRequest("POST", pushNotificationApiURL, payload)
-	if err != nil {
-		return 0, err
-	}
+// SendPushNotificationContext is like SendPushNotification but the request is cancelled as soon as ctx is done.
+func SendPushNotificationContext(ctx context.Context, input *PushNotificationInput) (int, error) {
+	return defaultClient.SendPushNotificationContext(ctx, input)
+}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", ApiKey))
-	req.Header.Add("Content-Type", "application/json")
+// SendPushNotification requests the Adalo API to send a push notification.
+// It returns the number of sent push notifications and any write error encountered.
+func (cl *Client) SendPushNotification(input *PushNotificationInput) (int, error) {
+	return cl.SendPushNotificationContext(context.Background(), input)
+}
 
-	res, err := client.Do(req)
-	if err != nil {
-		return 0, err
+// SendPushNotificationContext is like SendPushNotification but the request is cancelled as soon as ctx is done.
+func (cl *Client) SendPushNotificationContext(ctx context.Context, input *PushNotificationInput) (int, error) {
+	payload := *input
+	if payload.AppID == nil {
+		// using the app id of the client
+		appID := cl.AppID()
+		payload.AppID = &appID
 	}
-	defer res.Body.Close()
 
-	body, err := ioutil.ReadAll(res.Body)
+	_, body, err := cl.do(ctx, http.MethodPost, cl.notificationsBaseURL+"/notifications", &payload)
 	if err != nil {
 		return 0, err
 	}
 
-	var response map[string]interface{}
-	err = json.Unmarshal(body, &response)
-
-	if errorMessage, ok := response["error"]; ok {
-		return 0, errors.New(strings.ToLower(errorMessage.(string)))
+	var response pushNotificationResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, err
 	}
 
-	if successful, ok := response["successful"]; ok {
-		return successful.(int), nil
+	if response.Successful == nil {
+		return 0, errors.New("internal server error")
 	}
 
-	return 0, errors.New("internal server error")
+	return *response.Successful, nil
 }
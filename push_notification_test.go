@@ -1,6 +1,7 @@
 package adalo
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -37,7 +38,7 @@ func TestSendPushNotification(t *testing.T) {
 		})
 
 		assert.Error(t, err)
-		assert.Equal(t, ErrorUserNotFound, err)
+		assert.True(t, errors.Is(err, ErrorUserNotFound))
 		assert.Equal(t, 0, i)
 	})
 
@@ -54,7 +55,7 @@ func TestSendPushNotification(t *testing.T) {
 			},
 		})
 
-		assert.Equal(t, ErrorUnauthorized, err)
+		assert.True(t, errors.Is(err, ErrorUnauthorized))
 	})
 
 	t.Run("invalid app", func(t *testing.T) {
@@ -70,6 +71,6 @@ func TestSendPushNotification(t *testing.T) {
 			},
 		})
 
-		assert.Equal(t, ErrorAppMismatch, err)
+		assert.True(t, errors.Is(err, ErrorAppMismatch))
 	})
 }
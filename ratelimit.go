@@ -0,0 +1,157 @@
+package adalo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRateLimit is the number of requests per second a client sends at most by default,
+	// which matches the rate limit Adalo enforces per app.
+	DefaultRateLimit = 5
+
+	// DefaultRateLimitBurst is the number of requests a client may send at once by default.
+	DefaultRateLimitBurst = 5
+)
+
+// RateLimiter is a token bucket that limits the rate of requests sent to the Adalo API.
+// Requests exceeding the rate are delayed instead of being rejected by the API.
+// It is safe for concurrent use and can be shared by several clients of the same Adalo app.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	// rate is the number of tokens added to the bucket per second
+	rate float64
+
+	// burst is the maximum number of tokens in the bucket
+	burst int
+
+	// tokens is the number of available tokens, it is negative when requests are waiting
+	tokens float64
+
+	// last is the time tokens was last updated
+	last time.Time
+
+	// now returns the current time and sleep waits for d unless ctx is done, they are replaced in tests
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRateLimiter initializes a RateLimiter that allows rate requests per second
+// and bursts of up to burst requests. A rate of zero or below disables the limit.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+		sleep:  sleep,
+	}
+}
+
+// WithRateLimit limits the client to rate requests per second with bursts of up to burst requests.
+func WithRateLimit(rate float64, burst int) Option {
+	return func(cl *Client) {
+		cl.rateLimiter = NewRateLimiter(rate, burst)
+	}
+}
+
+// WithRateLimiter sets the RateLimiter of the client, e.g. to share it with other clients of the same app.
+// Passing nil disables rate limiting.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(cl *Client) {
+		cl.rateLimiter = limiter
+	}
+}
+
+// Rate returns the number of requests per second allowed by the limiter.
+func (l *RateLimiter) Rate() float64 {
+	return l.rate
+}
+
+// Burst returns the number of requests the limiter allows at once.
+func (l *RateLimiter) Burst() int {
+	return l.burst
+}
+
+// Wait blocks until a request may be sent or ctx is done.
+// It returns the error of the context if ctx is done before.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rate <= 0 {
+		return ctx.Err()
+	}
+
+	l.mu.Lock()
+	l.advance(l.now())
+	l.tokens--
+	wait := l.wait(l.tokens)
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return ctx.Err()
+	}
+
+	if err := l.sleep(ctx, wait); err != nil {
+		// give the reserved token back to the requests waiting after us
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Delay returns how long a request sent now would have to wait.
+func (l *RateLimiter) Delay() time.Duration {
+	if l == nil || l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance(l.now())
+	return l.wait(l.tokens - 1)
+}
+
+// advance adds the tokens that accumulated since the last update to the bucket.
+func (l *RateLimiter) advance(now time.Time) {
+	elapsed := now.Sub(l.last)
+	if elapsed <= 0 {
+		return
+	}
+	l.last = now
+	l.tokens += elapsed.Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+}
+
+// wait returns the time until the bucket holds the given number of tokens again.
+func (l *RateLimiter) wait(tokens float64) time.Duration {
+	if tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-tokens / l.rate * float64(time.Second))
+}
+
+// sleep waits for d and returns nil, or returns the error of the context if ctx is done before.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// RateLimiter returns the RateLimiter of the client, or nil if rate limiting is disabled.
+func (cl *Client) RateLimiter() *RateLimiter {
+	return cl.rateLimiter
+}
@@ -0,0 +1,154 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock replaces the clock of the limiter. Sleeping records the wait and advances the clock
+// unless frozen is set, or returns sleepErr if it is set.
+type fakeClock struct {
+	mu       sync.Mutex
+	now      time.Time
+	frozen   bool
+	waits    []time.Duration
+	sleepErr error
+}
+
+// install makes the limiter use the clock.
+func (c *fakeClock) install(limiter *RateLimiter) {
+	c.now = time.Date(2021, time.March, 4, 10, 0, 0, 0, time.UTC)
+	limiter.last = c.now
+	limiter.now = func() time.Time {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.now
+	}
+	limiter.sleep = func(ctx context.Context, d time.Duration) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.sleepErr != nil {
+			return c.sleepErr
+		}
+		c.waits = append(c.waits, d)
+		if !c.frozen {
+			c.now = c.now.Add(d)
+		}
+		return nil
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Run("allows bursts", func(t *testing.T) {
+		limiter := NewRateLimiter(1, 3)
+		clock := &fakeClock{}
+		clock.install(limiter)
+		for i := 0; i < 3; i++ {
+			assert.Nil(t, limiter.Wait(context.Background()))
+		}
+		assert.Empty(t, clock.waits)
+		assert.Equal(t, time.Second, limiter.Delay())
+	})
+
+	t.Run("smooths requests exceeding the burst", func(t *testing.T) {
+		limiter := NewRateLimiter(50, 1)
+		clock := &fakeClock{}
+		clock.install(limiter)
+		for i := 0; i < 4; i++ {
+			assert.Nil(t, limiter.Wait(context.Background()))
+		}
+		assert.Equal(t, []time.Duration{20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond}, clock.waits)
+	})
+
+	t.Run("stops waiting when context is done", func(t *testing.T) {
+		limiter := NewRateLimiter(0.1, 1)
+		clock := &fakeClock{}
+		clock.install(limiter)
+		assert.Nil(t, limiter.Wait(context.Background()))
+
+		clock.sleepErr = context.DeadlineExceeded
+		err := limiter.Wait(context.Background())
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		// the cancelled request must not delay the following ones
+		assert.Equal(t, 10*time.Second, limiter.Delay())
+	})
+
+	t.Run("sleeps until context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Equal(t, context.Canceled, sleep(ctx, time.Hour))
+		assert.Nil(t, sleep(context.Background(), time.Nanosecond))
+	})
+
+	t.Run("without limit", func(t *testing.T) {
+		var limiter *RateLimiter
+		assert.Nil(t, limiter.Wait(context.Background()))
+		assert.Equal(t, time.Duration(0), limiter.Delay())
+		assert.Equal(t, time.Duration(0), NewRateLimiter(0, 1).Delay())
+	})
+}
+
+func TestRateLimiter_Delay(t *testing.T) {
+	limiter := NewRateLimiter(10, 1)
+	clock := &fakeClock{}
+	clock.install(limiter)
+	assert.Equal(t, time.Duration(0), limiter.Delay())
+
+	assert.Nil(t, limiter.Wait(context.Background()))
+	assert.Equal(t, 100*time.Millisecond, limiter.Delay())
+
+	clock.now = clock.now.Add(40 * time.Millisecond)
+	assert.Equal(t, 60*time.Millisecond, limiter.Delay())
+}
+
+func TestClient_RateLimiter(t *testing.T) {
+	var requests int32
+	srv := newFlakyServer(0, http.StatusOK, &requests)
+	defer srv.Close()
+
+	limiter := NewRateLimiter(20, 1)
+	// the clock does not advance while sleeping, so the requests queue up behind each other
+	clock := &fakeClock{frozen: true}
+	clock.install(limiter)
+	client := NewClient("key", "app",
+		WithCollectionsBaseURL(srv.URL),
+		WithNotificationsBaseURL(srv.URL),
+		WithRateLimiter(limiter),
+	)
+	assert.Same(t, limiter, client.RateLimiter())
+
+	var sent int32
+	done := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		go func(i int) {
+			defer func() { done <- struct{}{} }()
+			var err error
+			if i%2 == 0 {
+				err = client.Collection("persons").Get(i, nil)
+			} else {
+				_, err = client.SendPushNotification(&PushNotificationInput{})
+			}
+			if err == nil {
+				atomic.AddInt32(&sent, 1)
+			}
+		}(i)
+	}
+	for i := 0; i < 4; i++ {
+		<-done
+	}
+
+	assert.Equal(t, int32(4), sent)
+	sort.Slice(clock.waits, func(i, j int) bool { return clock.waits[i] < clock.waits[j] })
+	assert.Equal(t, []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 150 * time.Millisecond}, clock.waits)
+
+	assert.NotNil(t, NewClient("key", "app").RateLimiter())
+	assert.Nil(t, NewClient("key", "app", WithRateLimiter(nil)).RateLimiter())
+}
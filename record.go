@@ -0,0 +1,44 @@
+package adalo
+
+import (
+	"context"
+	"time"
+)
+
+// Record contains the fields Adalo sets for every record of a collection.
+// Embed it in the structs records are bound to:
+//
+//	type Person struct {
+//		adalo.Record
+//		Name string `json:"Name"`
+//	}
+type Record struct {
+	// ID in Adalo collection
+	ID int `json:"id"`
+
+	// CreatedAt is the time the record was created in the collection
+	CreatedAt time.Time `json:"created_at"`
+
+	// UpdatedAt is the time of the last update of the record in the collection
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecordID returns the ID of the record.
+func (r Record) RecordID() int {
+	return r.ID
+}
+
+// Identifiable is implemented by records that know their ID, e.g. by all structs embedding Record.
+type Identifiable interface {
+	RecordID() int
+}
+
+// UpdateRecord updates the passed record with the input. It is a shorthand for UpdateContext with the ID of the record.
+func (c *Collection) UpdateRecord(ctx context.Context, record Identifiable, input interface{}, result interface{}) error {
+	return c.UpdateContext(ctx, record.RecordID(), input, result)
+}
+
+// DeleteRecord removes the passed record from the collection. It is a shorthand for DeleteContext with the ID of the record.
+func (c *Collection) DeleteRecord(ctx context.Context, record Identifiable) error {
+	return c.DeleteContext(ctx, record.RecordID())
+}
@@ -0,0 +1,67 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRecord_UnmarshalJSON(t *testing.T) {
+	var result person
+	err := json.Unmarshal([]byte(`{
+		"id": 12,
+		"Name": "John",
+		"created_at": "2021-03-04T10:15:30.250Z",
+		"updated_at": "2021-03-05T08:00:00.000Z"
+	}`), &result)
+	assert.Nil(t, err)
+	assert.Equal(t, 12, result.ID)
+	assert.Equal(t, 12, result.RecordID())
+	assert.Equal(t, "John", result.Name)
+	assert.True(t, result.CreatedAt.Equal(time.Date(2021, time.March, 4, 10, 15, 30, 250000000, time.UTC)))
+	assert.True(t, result.UpdatedAt.Equal(time.Date(2021, time.March, 5, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestCollection_UpdateRecord(t *testing.T) {
+	var last *http.Request
+	srv := newRecordingServer(&last)
+	defer srv.Close()
+
+	persons := NewClient("key", "app", WithCollectionsBaseURL(srv.URL)).Collection("persons")
+	record := person{Record: Record{ID: 7}, Name: "John"}
+
+	var result person
+	assert.Nil(t, persons.UpdateRecord(context.Background(), record, personInput{Name: "Jane"}, &result))
+	assert.Equal(t, http.MethodPut, last.Method)
+	assert.Equal(t, "/apps/app/collections/persons/7", last.URL.Path)
+
+	assert.Nil(t, persons.DeleteRecord(context.Background(), &record))
+	assert.Equal(t, http.MethodDelete, last.Method)
+	assert.Equal(t, "/apps/app/collections/persons/7", last.URL.Path)
+}
+
+func TestTypedCollection_UpdateRecord(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("persons", personInput{Name: "John", Age: 21})
+
+	persons := Typed[person, personInput](newTestClient(srv).Collection("persons"))
+	ctx := context.Background()
+
+	record, err := persons.Get(ctx, 1)
+	assert.Nil(t, err)
+
+	updated, err := persons.UpdateRecord(ctx, record, personInput{Name: "Jane", Age: 28})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, updated.ID)
+	assert.Equal(t, "Jane", updated.Name)
+	assert.Equal(t, 28, updated.Age)
+
+	assert.Nil(t, persons.DeleteRecord(ctx, updated))
+	_, ok := srv.Record("persons", 1)
+	assert.False(t, ok)
+}
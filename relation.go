@@ -0,0 +1,241 @@
+package adalo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go/internal/rawrecord"
+	"github.com/be-foo/adalo-sdk-go/types"
+	"reflect"
+	"strings"
+)
+
+// Relation is the value of a relationship field. See types.Relation.
+type Relation = types.Relation
+
+// include describes the related records to load for a relationship field.
+type include struct {
+	field   string
+	related *Collection
+}
+
+// Include returns a copy of the collection that loads the records related through field from the
+// related collection whenever records are fetched with All, Iterate or Get. The IDs in the field
+// are replaced with the related records, which can be bound to a Relation or a slice of records.
+// Related records that do not exist anymore are left out, a Relation bound to the field still lists
+// their IDs. IDs are deduplicated per page and fetched in parallel, respecting the rate limit of the
+// related collection.
+func (c *Collection) Include(field string, related *Collection) *Collection {
+	included := *c
+	included.includes = append(append([]include{}, c.includes...), include{field: field, related: related})
+	return &included
+}
+
+// WithoutIncludes returns a copy of the collection that does not load related records, see Include.
+func (c *Collection) WithoutIncludes() *Collection {
+	without := *c
+	without.includes = nil
+	return &without
+}
+
+// relationIDs holds the IDs of the relationship fields of a record by field name, if some of the related
+// records do not exist anymore. The IDs are kept out of the raw record, so the field can still be bound
+// to a slice of records, and are set on the Relation fields of the bound record with bind.
+type relationIDs map[string][]int
+
+// loadIncludes replaces the IDs in the relationship fields of the records with the related records.
+// It returns the relationIDs of each record whose relations are dangling, or nil if there are none.
+func (c *Collection) loadIncludes(ctx context.Context, records []json.RawMessage) ([]relationIDs, error) {
+	var dangling []relationIDs
+	for _, inc := range c.includes {
+		relations := make([]*Relation, len(records))
+		single := make([]bool, len(records))
+		var ids []int
+		seen := map[int]bool{}
+		for i, record := range records {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(record, &fields); err != nil {
+				return nil, err
+			}
+			value := bytes.TrimSpace(fields[inc.field])
+			if len(value) == 0 || bytes.Equal(value, []byte("null")) {
+				continue
+			}
+
+			single[i] = value[0] != '['
+			relations[i] = &Relation{}
+			if err := relations[i].UnmarshalJSON(value); err != nil {
+				return nil, fmt.Errorf("field %s: %w", inc.field, err)
+			}
+			for _, id := range relations[i].IDs {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+				}
+			}
+		}
+
+		related, err := inc.related.fetchByIDs(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("including %s: %w", inc.field, err)
+		}
+
+		for i, relation := range relations {
+			if relation == nil {
+				continue
+			}
+
+			loaded := []json.RawMessage{}
+			for _, id := range relation.IDs {
+				if record, ok := related[id]; ok {
+					loaded = append(loaded, record)
+				}
+			}
+			if len(loaded) < len(relation.IDs) {
+				if dangling == nil {
+					dangling = make([]relationIDs, len(records))
+				}
+				if dangling[i] == nil {
+					dangling[i] = relationIDs{}
+				}
+				dangling[i][inc.field] = relation.IDs
+			}
+
+			var value interface{} = loaded
+			if single[i] {
+				value = nil
+				if len(loaded) > 0 {
+					value = loaded[0]
+				}
+			}
+
+			records[i], err = replaceField(records[i], inc.field, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return dangling, nil
+}
+
+// bind sets the IDs on the Relation and *Relation fields of the record bound to v,
+// including the fields of embedded structs. Fields are matched by their json name like encoding/json does.
+func (ids relationIDs) bind(v reflect.Value) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if len(ids) == 0 || v.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		tag := field.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		switch {
+		case tag == "-":
+			continue
+		case field.Anonymous && name == "":
+			ids.bind(v.Field(i))
+			continue
+		case !field.IsExported() || !v.Field(i).CanSet():
+			continue
+		case name == "":
+			name = field.Name
+		}
+
+		for key, relationIDs := range ids {
+			if !strings.EqualFold(key, name) {
+				continue
+			}
+			switch value := v.Field(i).Addr().Interface().(type) {
+			case *Relation:
+				value.IDs = append([]int{}, relationIDs...)
+			case **Relation:
+				if *value == nil {
+					*value = &Relation{}
+				}
+				(*value).IDs = append([]int{}, relationIDs...)
+			}
+		}
+	}
+}
+
+// bindRelationIDs sets the IDs of the dangling relations on the records bound to result, which points to a slice.
+func bindRelationIDs(result interface{}, dangling []relationIDs) {
+	if dangling == nil {
+		return
+	}
+	v := reflect.ValueOf(result)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return
+	}
+	for i := 0; i < v.Len() && i < len(dangling); i++ {
+		dangling[i].bind(v.Index(i))
+	}
+}
+
+// fetchByIDs fetches the records with the given ids in parallel.
+// Records that do not exist anymore are left out of the result.
+func (c *Collection) fetchByIDs(ctx context.Context, ids []int) (map[int]json.RawMessage, error) {
+	result, err := c.runBatch(ctx, len(ids), BatchOptions{StopOnError: true}, func(ctx context.Context, i int) (int, json.RawMessage, error) {
+		var record json.RawMessage
+		err := c.GetContext(ctx, ids[i], &record)
+		if errors.Is(err, ErrorResourceNotFound) {
+			return ids[i], nil, nil
+		}
+		return ids[i], record, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := map[int]json.RawMessage{}
+	for _, item := range result.Items {
+		if item.Record != nil {
+			records[item.ID] = item.Record
+		}
+	}
+	return records, nil
+}
+
+// replaceField returns a copy of the raw record with the value of field replaced,
+// preserving the order of the fields.
+func replaceField(record json.RawMessage, field string, value interface{}) (json.RawMessage, error) {
+	keys, err := rawrecord.Keys(record)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(record, &fields); err != nil {
+		return nil, err
+	}
+	if fields[field], err = json.Marshal(value); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(fields[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
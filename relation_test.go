@@ -0,0 +1,200 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+// task represents a record in the Tasks collection used in the tests
+type task struct {
+	ID    int    `json:"id"`
+	Title string `json:"Title"`
+}
+
+// personWithTasks represents a person whose tasks are loaded
+type personWithTasks struct {
+	ID      int      `json:"id"`
+	Name    string   `json:"Name"`
+	Tasks   Relation `json:"Tasks"`
+	Reviews []task   `json:"Reviews"`
+}
+
+// relationTasks and relationPersons are the records of the tasks and persons collections in the relation tests.
+// Jane relates to task 99, which does not exist.
+var (
+	relationTasks   = []interface{}{task{Title: "Task 1"}, task{Title: "Task 2"}, task{Title: "Task 3"}}
+	relationPersons = []interface{}{
+		map[string]interface{}{"Name": "John", "Tasks": []int{1, 2}, "Mentor": 2, "Reviews": []int{3}},
+		map[string]interface{}{"Name": "Jane", "Tasks": []int{2, 3, 99}, "Mentor": nil, "Reviews": []int{}},
+	}
+)
+
+// tasksPath is the path prefix of the requests for single tasks.
+const tasksPath = "/apps/app/collections/tasks/"
+
+func TestCollection_Include(t *testing.T) {
+	t.Run("with all", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("tasks", relationTasks...).WithRecords("persons", relationPersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+
+		tasks := client.Collection("tasks")
+		persons := client.Collection("persons").Include("Tasks", tasks).Include("Reviews", tasks)
+
+		var result []personWithTasks
+		assert.Nil(t, persons.All(&result))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, tasksPath+"1"))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, tasksPath+"2"))
+		assert.Equal(t, 2, srv.CountRequests(http.MethodGet, tasksPath+"3"))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, tasksPath+"99"))
+		assert.Equal(t, 5, srv.CountRequests("", tasksPath))
+
+		assert.Equal(t, []int{1, 2}, result[0].Tasks.IDs)
+		assert.True(t, result[0].Tasks.Loaded())
+		var johnsTasks []task
+		assert.Nil(t, result[0].Tasks.Bind(&johnsTasks))
+		assert.Equal(t, []task{{1, "Task 1"}, {2, "Task 2"}}, johnsTasks)
+		assert.Equal(t, []task{{3, "Task 3"}}, result[0].Reviews)
+
+		// deleted related records are left out, but keep their id
+		assert.Equal(t, []int{2, 3, 99}, result[1].Tasks.IDs)
+		assert.Len(t, result[1].Tasks.Records, 2)
+		assert.Equal(t, []task{}, result[1].Reviews)
+	})
+
+	t.Run("with list", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("tasks", relationTasks...).WithRecords("persons", relationPersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+
+		persons := client.Collection("persons").Include("Tasks", client.Collection("tasks"))
+
+		page, err := persons.List(context.Background(), ListOptions{})
+		assert.Nil(t, err)
+		var result []struct {
+			Tasks Relation `json:"Tasks"`
+		}
+		assert.Nil(t, page.Bind(&result))
+		assert.Equal(t, 0, srv.CountRequests("", tasksPath))
+		assert.Equal(t, []int{1, 2}, result[0].Tasks.IDs)
+		assert.False(t, result[0].Tasks.Loaded())
+	})
+
+	t.Run("without includes", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("tasks", relationTasks...).WithRecords("persons", relationPersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+
+		persons := client.Collection("persons").Include("Tasks", client.Collection("tasks"))
+
+		var result []struct {
+			Tasks Relation `json:"Tasks"`
+		}
+		assert.Nil(t, persons.WithoutIncludes().All(&result))
+		assert.Equal(t, 0, srv.CountRequests("", tasksPath))
+		assert.Len(t, persons.includes, 1)
+	})
+
+	t.Run("with single relation", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("tasks", relationTasks...).WithRecords("persons", relationPersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+
+		persons := client.Collection("persons")
+
+		var result []struct {
+			Name   string  `json:"Name"`
+			Mentor *person `json:"Mentor"`
+		}
+		assert.Nil(t, persons.Include("Mentor", persons).All(&result))
+		if assert.NotNil(t, result[0].Mentor) {
+			assert.Equal(t, "Jane", result[0].Mentor.Name)
+		}
+		assert.Nil(t, result[1].Mentor)
+	})
+
+	t.Run("with get", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("tasks", relationTasks...).WithRecords("persons", relationPersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+
+		persons := Typed[personWithTasks, personInput](client.Collection("persons")).Include("Tasks", client.Collection("tasks"))
+
+		result, err := persons.Get(context.Background(), 2)
+		assert.Nil(t, err)
+		var tasks []task
+		assert.Nil(t, result.Tasks.Bind(&tasks))
+		assert.Equal(t, []task{{2, "Task 2"}, {3, "Task 3"}}, tasks)
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, tasksPath+"2"))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, tasksPath+"3"))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, tasksPath+"99"))
+		assert.Equal(t, 3, srv.CountRequests("", tasksPath))
+	})
+
+	t.Run("with dangling relations", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("tasks", relationTasks...).WithRecords("persons", relationPersons...)
+		defer srv.Close()
+		client := newTestClient(srv)
+		srv.Seed("persons", map[string]interface{}{"Name": "Max", "Tasks": []int{1, 99}, "Mentor": 98})
+
+		persons := client.Collection("persons")
+		persons = persons.Include("Tasks", client.Collection("tasks")).Include("Mentor", persons)
+
+		var records []struct {
+			Tasks  []task  `json:"Tasks"`
+			Mentor *person `json:"Mentor"`
+		}
+		assert.Nil(t, persons.All(&records))
+		assert.Equal(t, []task{{2, "Task 2"}, {3, "Task 3"}}, records[1].Tasks)
+		assert.Equal(t, []task{{1, "Task 1"}}, records[2].Tasks)
+		assert.Nil(t, records[2].Mentor)
+
+		var relations []struct {
+			Tasks  Relation  `json:"Tasks"`
+			Mentor *Relation `json:"Mentor"`
+		}
+		assert.Nil(t, persons.All(&relations))
+		assert.Equal(t, []int{1, 2}, relations[0].Tasks.IDs)
+		assert.Equal(t, []int{1, 99}, relations[2].Tasks.IDs)
+		assert.Len(t, relations[2].Tasks.Records, 1)
+		if assert.NotNil(t, relations[2].Mentor) {
+			assert.Equal(t, []int{98}, relations[2].Mentor.IDs)
+			assert.False(t, relations[2].Mentor.Loaded())
+		}
+
+		it := persons.Iterate(context.Background(), ListOptions{Offset: 2})
+		if assert.True(t, it.Next()) {
+			var record struct {
+				Tasks []task `json:"Tasks"`
+			}
+			assert.Nil(t, it.Scan(&record))
+			assert.Equal(t, []task{{1, "Task 1"}}, record.Tasks)
+
+			var relation struct {
+				Tasks Relation `json:"tasks"`
+			}
+			assert.Nil(t, it.Scan(&relation))
+			assert.Equal(t, []int{1, 99}, relation.Tasks.IDs)
+		}
+
+		result, err := Typed[personWithTasks, personInput](persons).Get(context.Background(), 3)
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 99}, result.Tasks.IDs)
+	})
+
+	t.Run("does not change the collection", func(t *testing.T) {
+		persons := NewCollection("persons")
+		persons.Include("Tasks", NewCollection("tasks"))
+		assert.Empty(t, persons.includes)
+	})
+}
+
+func TestReplaceField(t *testing.T) {
+	record, err := replaceField(json.RawMessage(`{"b": 1, "a": [1, 2], "c": "x"}`), "a", []string{"y"})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"b":1,"a":["y"],"c":"x"}`, string(record))
+}
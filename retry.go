@@ -0,0 +1,101 @@
+package adalo
+
+import (
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how requests that failed with a retryable error are retried.
+// See IsRetryable for the errors that are considered retryable.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry, it is doubled with each further retry
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent enables retries of requests that are not idempotent, i.e. Insert
+	// and SendPushNotification. Retrying them may create duplicate records or notifications.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is the RetryPolicy used by clients unless configured otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  10 * time.Second,
+}
+
+// NoRetries is a RetryPolicy that disables retries.
+var NoRetries = RetryPolicy{MaxAttempts: 1}
+
+// WithRetryPolicy sets the RetryPolicy of the client.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cl *Client) {
+		cl.retryPolicy = policy
+	}
+}
+
+// shouldRetry reports whether a request with the given method that failed with err
+// should be sent again after the given number of attempts.
+func (p RetryPolicy) shouldRetry(method string, attempts int, err error) bool {
+	if attempts >= p.MaxAttempts || !IsRetryable(err) {
+		return false
+	}
+	return isIdempotent(method) || p.RetryNonIdempotent
+}
+
+// backoff returns the delay before the next attempt after the given number of attempts failed.
+// The delay grows exponentially with jitter, but is at least as long as requested
+// by the Retry-After header of a response.
+func (p RetryPolicy) backoff(attempts int, err error) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempts && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay > 0 {
+		// equal jitter: wait at least half of the delay
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if retryAfter, ok := parseRetryAfter(apiErr.Header.Get("Retry-After")); ok && retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+	return delay
+}
+
+// isIdempotent reports whether requests with the given method can safely be sent more than once.
+func isIdempotent(method string) bool {
+	return method != http.MethodPost
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or a HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
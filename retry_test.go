@@ -0,0 +1,144 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testRetryPolicy retries quickly to keep the tests fast.
+var testRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+// newFlakyServer starts a test server that responds with the given status code
+// to the first failures requests and with a record afterwards.
+func newFlakyServer(failures int32, status int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(requests, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": 1, "successful": 1}`))
+	}))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("retries idempotent requests", func(t *testing.T) {
+		var requests int32
+		srv := newFlakyServer(2, http.StatusTooManyRequests, &requests)
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy))
+		err := client.Collection("persons").Get(1, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(3), requests)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		var requests int32
+		srv := newFlakyServer(5, http.StatusBadGateway, &requests)
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy))
+		err := client.Collection("persons").Delete(1)
+		assert.True(t, IsRetryable(err))
+		assert.Equal(t, int32(3), requests)
+	})
+
+	t.Run("does not retry non-retryable errors", func(t *testing.T) {
+		var requests int32
+		srv := newFlakyServer(1, http.StatusBadRequest, &requests)
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy))
+		err := client.Collection("persons").Update(1, map[string]interface{}{}, nil)
+		assert.Error(t, err)
+		assert.Equal(t, int32(1), requests)
+	})
+
+	t.Run("does not retry insert by default", func(t *testing.T) {
+		var requests int32
+		srv := newFlakyServer(1, http.StatusServiceUnavailable, &requests)
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithNotificationsBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy))
+		err := client.Collection("persons").Insert(map[string]interface{}{}, nil)
+		assert.True(t, IsRetryable(err))
+		_, err = client.SendPushNotification(&PushNotificationInput{})
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), requests)
+	})
+
+	t.Run("retries insert when enabled", func(t *testing.T) {
+		var requests int32
+		srv := newFlakyServer(1, http.StatusServiceUnavailable, &requests)
+		defer srv.Close()
+
+		policy := testRetryPolicy
+		policy.RetryNonIdempotent = true
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithRetryPolicy(policy))
+		err := client.Collection("persons").Insert(map[string]interface{}{}, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), requests)
+	})
+
+	t.Run("stops waiting when context is done", func(t *testing.T) {
+		var requests int32
+		srv := newFlakyServer(5, http.StatusServiceUnavailable, &requests)
+		defer srv.Close()
+
+		client := NewClient("key", "app", WithCollectionsBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{
+			MaxAttempts: 3,
+			MinBackoff:  time.Minute,
+			MaxBackoff:  time.Minute,
+		}))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := client.Collection("persons").GetContext(ctx, 1, nil)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, int32(1), requests)
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempts, max := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		10: time.Second,
+	} {
+		delay := policy.backoff(attempts, nil)
+		assert.True(t, delay >= max/2 && delay <= max, "attempt %d: %s", attempts, delay)
+	}
+
+	err := &APIError{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}}
+	assert.Equal(t, 30*time.Second, policy.backoff(1, err))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("120")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.True(t, d > 59*time.Minute && d <= time.Hour)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+
+	_, ok = parseRetryAfter("")
+	assert.False(t, ok)
+}
@@ -0,0 +1,143 @@
+package adalo
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FieldType is the type of a field in an Adalo collection.
+type FieldType string
+
+// list of field types supported by Adalo collections
+const (
+	// FieldText is a text field, represented as JSON string
+	FieldText FieldType = "text"
+
+	// FieldNumber is a number field, represented as JSON number
+	FieldNumber FieldType = "number"
+
+	// FieldBoolean is a true/false field, represented as JSON boolean
+	FieldBoolean FieldType = "boolean"
+
+	// FieldDate is a date field, represented as JSON string in the format 2006-01-02
+	FieldDate FieldType = "date"
+
+	// FieldDateTime is a date & time field, represented as JSON string in RFC 3339 format
+	FieldDateTime FieldType = "datetime"
+
+	// FieldImage is an image field, represented as JSON object with the url and metadata of the image
+	FieldImage FieldType = "image"
+
+	// FieldFile is a file field, represented as JSON object with the url and metadata of the file
+	FieldFile FieldType = "file"
+
+	// FieldLocation is a location field, represented as JSON object with the address and coordinates
+	FieldLocation FieldType = "location"
+
+	// FieldRelationship is a relationship to another collection, represented as JSON array of record IDs
+	FieldRelationship FieldType = "relationship"
+
+	// FieldJSON is a field whose values have no matching Adalo type, e.g. arrays of texts, kept as raw JSON
+	FieldJSON FieldType = "json"
+)
+
+// Valid reports whether t is one of the field types supported by Adalo.
+func (t FieldType) Valid() bool {
+	switch t {
+	case FieldText, FieldNumber, FieldBoolean, FieldDate, FieldDateTime,
+		FieldImage, FieldFile, FieldLocation, FieldRelationship, FieldJSON:
+		return true
+	}
+	return false
+}
+
+// Schema describes the collections of an Adalo app.
+// It is read by the adalo-gen command to generate typed collections.
+type Schema struct {
+	// Package is the name of the Go package generated code belongs to
+	Package string `json:"package,omitempty" yaml:"package,omitempty"`
+
+	// Collections lists the described collections
+	Collections []CollectionSchema `json:"collections" yaml:"collections"`
+}
+
+// ReadSchema reads the schema file at the given path, which is parsed as JSON if it has the .json
+// extension and as YAML otherwise.
+func ReadSchema(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema Schema
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &schema)
+	} else {
+		err = yaml.Unmarshal(data, &schema)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing schema %s: %w", path, err)
+	}
+	return &schema, nil
+}
+
+// CollectionSchema describes an Adalo collection.
+type CollectionSchema struct {
+	// Name is the name of the Go type representing a record of the collection, e.g. Person
+	Name string `json:"name" yaml:"name"`
+
+	// ID of collection in Adalo
+	ID string `json:"id" yaml:"id"`
+
+	// Fields lists the fields of the collection except id, created_at and updated_at
+	Fields []FieldSchema `json:"fields" yaml:"fields"`
+}
+
+// FieldSchema describes a field of an Adalo collection.
+type FieldSchema struct {
+	// Name of the field in Adalo, which may contain spaces, e.g. "Date of Birth"
+	Name string `json:"name" yaml:"name"`
+
+	// Type of the field
+	Type FieldType `json:"type" yaml:"type"`
+
+	// Nullable indicates that the field may be empty
+	Nullable bool `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+
+	// GoName overrides the name of the Go struct field, which is derived from Name by default
+	GoName string `json:"goName,omitempty" yaml:"goName,omitempty"`
+
+	// Observed summarizes the values of the field if the schema was created by InferSchema
+	Observed *FieldStats `json:"observed,omitempty" yaml:"observed,omitempty"`
+}
+
+// Validate checks that every collection has a name and an ID and that all field types are valid.
+func (s *Schema) Validate() error {
+	names := map[string]bool{}
+	for i, collection := range s.Collections {
+		if collection.Name == "" {
+			return fmt.Errorf("collection %d: name is missing", i)
+		}
+		if names[collection.Name] {
+			return fmt.Errorf("collection %s: name is not unique", collection.Name)
+		}
+		names[collection.Name] = true
+		if collection.ID == "" {
+			return fmt.Errorf("collection %s: id is missing", collection.Name)
+		}
+
+		for j, field := range collection.Fields {
+			if field.Name == "" {
+				return fmt.Errorf("collection %s: field %d: name is missing", collection.Name, j)
+			}
+			if !field.Type.Valid() {
+				return fmt.Errorf("collection %s: field %s: invalid type %q", collection.Name, field.Name, field.Type)
+			}
+		}
+	}
+	return nil
+}
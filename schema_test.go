@@ -0,0 +1,60 @@
+package adalo
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSchema_Validate(t *testing.T) {
+	t.Run("valid schema", func(t *testing.T) {
+		schema := &Schema{Collections: []CollectionSchema{{
+			Name:   "Person",
+			ID:     "t_1",
+			Fields: []FieldSchema{{Name: "Name", Type: FieldText}, {Name: "Tasks", Type: FieldRelationship}},
+		}}}
+		assert.Nil(t, schema.Validate())
+	})
+
+	t.Run("invalid schemas", func(t *testing.T) {
+		for _, schema := range []*Schema{
+			{Collections: []CollectionSchema{{ID: "t_1"}}},
+			{Collections: []CollectionSchema{{Name: "Person"}}},
+			{Collections: []CollectionSchema{{Name: "Person", ID: "t_1"}, {Name: "Person", ID: "t_2"}}},
+			{Collections: []CollectionSchema{{Name: "Person", ID: "t_1", Fields: []FieldSchema{{Type: FieldText}}}}},
+			{Collections: []CollectionSchema{{Name: "Person", ID: "t_1", Fields: []FieldSchema{{Name: "Name", Type: "string"}}}}},
+		} {
+			assert.Error(t, schema.Validate())
+		}
+	})
+}
+
+func TestReadSchema(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"schema.json": `{"package": "models", "collections": [{"name": "Person", "id": "t_1", "fields": [{"name": "Name", "type": "text"}]}]}`,
+		"schema.yaml": "package: models\ncollections:\n  - name: Person\n    id: t_1\n    fields:\n      - name: Name\n        type: text\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if !assert.Nil(t, os.WriteFile(path, []byte(content), 0644)) {
+			return
+		}
+
+		schema, err := ReadSchema(path)
+		if assert.Nil(t, err, name) {
+			assert.Equal(t, &Schema{Package: "models", Collections: []CollectionSchema{{
+				Name: "Person", ID: "t_1", Fields: []FieldSchema{{Name: "Name", Type: FieldText}},
+			}}}, schema, name)
+		}
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.Nil(t, os.WriteFile(invalid, []byte("collections:"), 0644))
+	_, err := ReadSchema(invalid)
+	assert.Error(t, err)
+
+	_, err = ReadSchema(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
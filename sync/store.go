@@ -0,0 +1,206 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	gosync "sync"
+	"time"
+)
+
+// Checkpoint is the state of a replicated collection after a successful sync.
+type Checkpoint struct {
+	// Records maps the IDs of the replicated records to their updated_at, used to detect updated and deleted records
+	Records map[int]time.Time `json:"records"`
+
+	// SyncedAt is the time the sync completed
+	SyncedAt time.Time `json:"syncedAt"`
+}
+
+// Store persists the replicated records of collections.
+// Implementations must tolerate records being written again, since a failed sync is repeated as a whole.
+type Store interface {
+	// Upsert inserts or replaces the raw records of the collection
+	Upsert(ctx context.Context, collectionID string, records []json.RawMessage) error
+
+	// Delete removes the records with the given ids from the collection
+	Delete(ctx context.Context, collectionID string, ids []int) error
+
+	// Checkpoint returns the checkpoint of the collection, or nil if it was never synced
+	Checkpoint(ctx context.Context, collectionID string) (*Checkpoint, error)
+
+	// SaveCheckpoint stores the checkpoint of the collection after all changes were written
+	SaveCheckpoint(ctx context.Context, collectionID string, checkpoint *Checkpoint) error
+}
+
+// FileStore is a Store keeping the records of each collection in a JSON file in a directory.
+// Records are held in memory and written to the file together with the checkpoint,
+// so the file always contains a consistent state of the collection.
+type FileStore struct {
+	dir string
+
+	mu          gosync.Mutex
+	collections map[string]*fileCollection
+}
+
+// fileCollection is the content of the file of a collection.
+type fileCollection struct {
+	Checkpoint *Checkpoint       `json:"checkpoint"`
+	Records    []json.RawMessage `json:"records"`
+
+	// records holds the records by their id
+	records map[int]json.RawMessage
+}
+
+// NewFileStore returns a FileStore writing to the directory dir, which is created if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir, collections: map[string]*fileCollection{}}, nil
+}
+
+// Upsert inserts or replaces the records of the collection in memory.
+func (s *FileStore) Upsert(ctx context.Context, collectionID string, records []json.RawMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(collectionID)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		id, err := types.RecordID(record)
+		if err != nil {
+			return err
+		}
+		c.records[id] = record
+	}
+	return nil
+}
+
+// Delete removes the records from the collection in memory.
+func (s *FileStore) Delete(ctx context.Context, collectionID string, ids []int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(collectionID)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		delete(c.records, id)
+	}
+	return nil
+}
+
+// Checkpoint returns the checkpoint stored in the file of the collection.
+func (s *FileStore) Checkpoint(ctx context.Context, collectionID string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return c.Checkpoint, nil
+}
+
+// SaveCheckpoint writes the records and the checkpoint of the collection to its file.
+// The file is replaced atomically, so it is never left half written.
+func (s *FileStore) SaveCheckpoint(ctx context.Context, collectionID string, checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(collectionID)
+	if err != nil {
+		return err
+	}
+	c.Checkpoint = checkpoint
+	c.Records = c.sorted()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, collectionID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(collectionID))
+}
+
+// Records returns the records of the collection ordered by ID, including changes not saved with a checkpoint yet.
+func (s *FileStore) Records(collectionID string) ([]json.RawMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(collectionID)
+	if err != nil {
+		return nil, err
+	}
+	return c.sorted(), nil
+}
+
+// collection returns the collection, reading it from its file on first access. The caller must hold s.mu.
+func (s *FileStore) collection(collectionID string) (*fileCollection, error) {
+	if c, ok := s.collections[collectionID]; ok {
+		return c, nil
+	}
+
+	c := &fileCollection{records: map[int]json.RawMessage{}}
+	data, err := os.ReadFile(s.path(collectionID))
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", s.path(collectionID), err)
+		}
+		for _, record := range c.Records {
+			id, err := types.RecordID(record)
+			if err != nil {
+				return nil, err
+			}
+			c.records[id] = record
+		}
+		c.Records = nil
+	}
+
+	s.collections[collectionID] = c
+	return c, nil
+}
+
+// path returns the path of the file of the collection.
+func (s *FileStore) path(collectionID string) string {
+	return filepath.Join(s.dir, collectionID+".json")
+}
+
+// sorted returns the records ordered by ID.
+func (c *fileCollection) sorted() []json.RawMessage {
+	ids := make([]int, 0, len(c.records))
+	for id := range c.records {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	records := make([]json.RawMessage, len(ids))
+	for i, id := range ids {
+		records[i] = c.records[id]
+	}
+	return records
+}
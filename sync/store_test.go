@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	store, err := NewFileStore(dir)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	checkpoint, err := store.Checkpoint(ctx, "persons")
+	assert.Nil(t, err)
+	assert.Nil(t, checkpoint)
+
+	assert.Nil(t, store.Upsert(ctx, "persons", []json.RawMessage{
+		json.RawMessage(`{"id": 2, "Name": "Jane"}`),
+		json.RawMessage(`{"id": 1, "Name": "John"}`),
+		json.RawMessage(`{"id": 3, "Name": "Richard"}`),
+	}))
+	assert.Nil(t, store.Delete(ctx, "persons", []int{3}))
+	assert.Error(t, store.Upsert(ctx, "persons", []json.RawMessage{json.RawMessage(`[]`)}))
+
+	records, err := store.Records("persons")
+	assert.Nil(t, err)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`{"id": 1, "Name": "John"}`), json.RawMessage(`{"id": 2, "Name": "Jane"}`)}, records)
+
+	// changes are only persisted with the checkpoint
+	_, err = os.Stat(filepath.Join(dir, "persons.json"))
+	assert.True(t, os.IsNotExist(err))
+
+	saved := &Checkpoint{
+		Records: map[int]time.Time{
+			1: time.Date(2021, time.March, 4, 10, 0, 0, 0, time.UTC),
+			2: time.Date(2021, time.March, 3, 10, 0, 0, 0, time.UTC),
+		},
+		SyncedAt: time.Date(2021, time.March, 5, 10, 0, 0, 0, time.UTC),
+	}
+	assert.Nil(t, store.SaveCheckpoint(ctx, "persons", saved))
+
+	reopened, err := NewFileStore(dir)
+	assert.Nil(t, err)
+	checkpoint, err = reopened.Checkpoint(ctx, "persons")
+	assert.Nil(t, err)
+	assert.Equal(t, saved, checkpoint)
+
+	records, err = reopened.Records("persons")
+	assert.Nil(t, err)
+	assert.Len(t, records, 2)
+	assert.JSONEq(t, `{"id": 2, "Name": "Jane"}`, string(records[1]))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*"))
+	assert.Equal(t, []string{filepath.Join(dir, "persons.json")}, files)
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0644))
+	_, err = reopened.Checkpoint(ctx, "broken")
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+// Package sync replicates Adalo collections into a local Store, e.g. for reporting.
+//
+// The first sync of a collection loads all records. Later syncs only write records that were inserted
+// or updated since the last checkpoint and remove records that were deleted in Adalo. Since the Adalo API
+// cannot filter records by updated_at, every sync still lists all records of the collection page by page,
+// but the store is only touched for changes.
+//
+//	store, err := sync.NewFileStore("replica")
+//	syncer := sync.NewSyncer(store, client.Collection("<ID-OF-PERSON-COLLECTION>"))
+//	err = syncer.Run(ctx, 5*time.Minute, func(stats []sync.Stats, err error) {
+//		log.Println(stats, err)
+//	})
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go"
+	"sort"
+	"time"
+)
+
+// Stats summarizes the changes applied to the store by the sync of a collection.
+type Stats struct {
+	// Collection is the ID of the synced collection
+	Collection string
+
+	// Full is set for the initial sync of a collection without checkpoint
+	Full bool
+
+	// Inserted, Updated and Deleted count the records written to or removed from the store
+	Inserted int
+	Updated  int
+	Deleted  int
+
+	// Unchanged counts the records that were not written because they did not change
+	Unchanged int
+}
+
+// Syncer replicates collections into a Store.
+type Syncer struct {
+	store       Store
+	collections []*adalo.Collection
+
+	// now returns the current time, it is replaced in tests
+	now func() time.Time
+}
+
+// NewSyncer returns a Syncer replicating the collections into the store.
+func NewSyncer(store Store, collections ...*adalo.Collection) *Syncer {
+	return &Syncer{store: store, collections: collections, now: time.Now}
+}
+
+// Run syncs the collections immediately and then every interval until ctx is done, which is returned as error.
+// onSync, if not nil, is called with the result of every sync. Failed syncs are repeated in the next interval.
+// Run returns an error without syncing if interval is not positive.
+func (s *Syncer) Run(ctx context.Context, interval time.Duration, onSync func(stats []Stats, err error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid sync interval %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		stats, err := s.Sync(ctx)
+		if onSync != nil {
+			onSync(stats, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			// select picks randomly when ctx was done during the sync and the ticker fired as well
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Sync syncs each collection once. It stops at the first collection that fails
+// and returns the stats of the collections synced until then.
+func (s *Syncer) Sync(ctx context.Context) ([]Stats, error) {
+	var all []Stats
+	for _, c := range s.collections {
+		stats, err := s.syncCollection(ctx, c)
+		if err != nil {
+			return all, fmt.Errorf("syncing collection %s: %w", c.ID, err)
+		}
+		all = append(all, *stats)
+	}
+	return all, nil
+}
+
+// syncCollection writes the changes of the collection since its checkpoint to the store and saves a new checkpoint.
+func (s *Syncer) syncCollection(ctx context.Context, c *adalo.Collection) (*Stats, error) {
+	// responses must not be served from the cache, and relationships are stored as IDs
+	c = c.Cached(0).WithoutIncludes()
+
+	checkpoint, err := s.store.Checkpoint(ctx, c.ID)
+	if err != nil {
+		return nil, err
+	}
+	cs := &collectionSync{
+		stats: &Stats{Collection: c.ID, Full: checkpoint == nil},
+		known: map[int]time.Time{},
+		next:  &Checkpoint{Records: map[int]time.Time{}},
+	}
+	if checkpoint != nil {
+		cs.known = checkpoint.Records
+	}
+
+	opts := adalo.ListOptions{}
+	for {
+		page, err := c.List(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.write(ctx, c, cs, page.Records); err != nil {
+			return nil, err
+		}
+		if !page.HasMore() {
+			break
+		}
+		opts = page.Next()
+	}
+
+	deleted, recovered, err := s.deleted(ctx, c, cs)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.write(ctx, c, cs, recovered); err != nil {
+		return nil, err
+	}
+	if len(deleted) > 0 {
+		if err := s.store.Delete(ctx, c.ID, deleted); err != nil {
+			return nil, err
+		}
+		cs.stats.Deleted = len(deleted)
+	}
+
+	cs.next.SyncedAt = s.now()
+	if err := s.store.SaveCheckpoint(ctx, c.ID, cs.next); err != nil {
+		return nil, err
+	}
+	return cs.stats, nil
+}
+
+// collectionSync is the state of the sync of a collection.
+type collectionSync struct {
+	stats *Stats
+
+	// known maps the IDs of the records in the previous checkpoint to their updated_at
+	known map[int]time.Time
+
+	// next is the checkpoint saved after the sync, its records are the records seen so far
+	next *Checkpoint
+}
+
+// write writes the records that were inserted or updated since the previous checkpoint to the store
+// and adds all records to the next checkpoint. Records that were already seen are skipped.
+func (s *Syncer) write(ctx context.Context, c *adalo.Collection, cs *collectionSync, records []json.RawMessage) error {
+	var changed []json.RawMessage
+	for _, record := range records {
+		var r adalo.Record
+		if err := json.Unmarshal(record, &r); err != nil {
+			return err
+		}
+		if _, ok := cs.next.Records[r.ID]; ok {
+			// records shift to the next page when records of previous pages are deleted
+			continue
+		}
+		cs.next.Records[r.ID] = r.UpdatedAt
+
+		updatedAt, ok := cs.known[r.ID]
+		switch {
+		case !ok:
+			cs.stats.Inserted++
+		case !r.UpdatedAt.Equal(updatedAt):
+			cs.stats.Updated++
+		default:
+			cs.stats.Unchanged++
+			continue
+		}
+		changed = append(changed, record)
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+	return s.store.Upsert(ctx, c.ID, changed)
+}
+
+// deleted returns the ids of the known records that were not seen while listing the collection and do not exist anymore.
+// It also returns the records that were missed because records shifted between pages, which still have to be written.
+func (s *Syncer) deleted(ctx context.Context, c *adalo.Collection, cs *collectionSync) ([]int, []json.RawMessage, error) {
+	var missing []int
+	for id := range cs.known {
+		if _, ok := cs.next.Records[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	sort.Ints(missing)
+
+	var deleted []int
+	var recovered []json.RawMessage
+	for _, id := range missing {
+		var record json.RawMessage
+		err := c.GetContext(ctx, id, &record)
+		switch {
+		case errors.Is(err, adalo.ErrorResourceNotFound):
+			deleted = append(deleted, id)
+		case err != nil:
+			return nil, nil, err
+		default:
+			recovered = append(recovered, record)
+		}
+	}
+	return deleted, recovered, nil
+}
@@ -0,0 +1,219 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/be-foo/adalo-sdk-go/adalotest/testclient"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	gosync "sync"
+	"testing"
+	"time"
+)
+
+// personRecords returns the given number of records for the persons collection.
+func personRecords(count int) []interface{} {
+	records := make([]interface{}, count)
+	for i := range records {
+		records[i] = map[string]interface{}{"Name": "Person", "Age": i}
+	}
+	return records
+}
+
+// names returns the names of the stored records.
+func names(t *testing.T, store *FileStore) map[int]string {
+	records, err := store.Records("persons")
+	assert.Nil(t, err)
+
+	result := map[int]string{}
+	for _, record := range records {
+		var r struct {
+			ID   int    `json:"id"`
+			Name string `json:"Name"`
+		}
+		assert.Nil(t, json.Unmarshal(record, &r))
+		result[r.ID] = r.Name
+	}
+	return result
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", personRecords(150)...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+	ctx := context.Background()
+
+	store, err := NewFileStore(t.TempDir())
+	if !assert.Nil(t, err) {
+		return
+	}
+	syncer := NewSyncer(store, persons)
+
+	stats, err := syncer.Sync(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Stats{{Collection: "persons", Full: true, Inserted: 150}}, stats)
+	assert.Len(t, names(t, store), 150)
+
+	stats, err = syncer.Sync(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Stats{{Collection: "persons", Unchanged: 150}}, stats)
+
+	assert.Nil(t, persons.Update(3, map[string]string{"Name": "Updated"}, nil))
+	assert.Nil(t, persons.Insert(map[string]string{"Name": "Inserted"}, nil))
+	assert.Nil(t, persons.Delete(1))
+	assert.Nil(t, persons.Delete(120))
+
+	stats, err = syncer.Sync(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Stats{{Collection: "persons", Inserted: 1, Updated: 1, Deleted: 2, Unchanged: 147}}, stats)
+
+	stored := names(t, store)
+	assert.Len(t, stored, 149)
+	assert.Equal(t, "Updated", stored[3])
+	assert.Equal(t, "Inserted", stored[151])
+	assert.NotContains(t, stored, 1)
+	assert.NotContains(t, stored, 120)
+
+	checkpoint, err := store.Checkpoint(ctx, "persons")
+	assert.Nil(t, err)
+	assert.Len(t, checkpoint.Records, 149)
+	assert.False(t, checkpoint.SyncedAt.IsZero())
+}
+
+func TestSyncer_Sync_updatedAt(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("persons",
+		map[string]interface{}{"Name": "John", "updated_at": "2021-01-01T10:00:00.000Z"},
+		map[string]interface{}{"Name": "Jane", "updated_at": "2021-06-01T10:00:00.000Z"},
+	)
+	ctx := context.Background()
+
+	store, _ := NewFileStore(t.TempDir())
+	syncer := NewSyncer(store, testclient.New(srv).Collection("persons"))
+	_, err := syncer.Sync(ctx)
+	assert.Nil(t, err)
+
+	// the update is older than the latest updated_at of the collection, but newer than the stored record
+	srv.Seed("persons", map[string]interface{}{"id": 1, "Name": "Johnny", "updated_at": "2021-02-01T10:00:00.000Z"})
+
+	stats, err := syncer.Sync(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Stats{{Collection: "persons", Updated: 1, Unchanged: 1}}, stats)
+	assert.Equal(t, "Johnny", names(t, store)[1])
+}
+
+// roundTripFunc is an http.RoundTripper calling the function.
+type roundTripFunc func(r *http.Request) (*http.Response, error)
+
+// RoundTrip calls f.
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestSyncer_Sync_shifted(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("tasks", map[string]interface{}{"Title": "Task"})
+	for i := 0; i < 150; i++ {
+		srv.Seed("persons", map[string]interface{}{"Name": "Person", "Tasks": []int{1}})
+	}
+	ctx := context.Background()
+	persons := testclient.New(srv).Collection("persons")
+
+	store, _ := NewFileStore(t.TempDir())
+	_, err := NewSyncer(store, persons).Sync(ctx)
+	assert.Nil(t, err)
+
+	// deleting record 1 after the first page was listed shifts record 101 to the first page, so it is missed
+	var once gosync.Once
+	shifting := testclient.New(srv, adalo.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		res, err := http.DefaultTransport.RoundTrip(r)
+		if r.URL.Query().Get("offset") == "0" {
+			once.Do(func() {
+				assert.Nil(t, persons.Delete(1))
+				assert.Nil(t, persons.Update(101, map[string]string{"Name": "Updated"}, nil))
+			})
+		}
+		return res, err
+	})))
+	tasks := testclient.New(srv).Collection("tasks")
+
+	stats, err := NewSyncer(store, shifting.Collection("persons").Include("Tasks", tasks)).Sync(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []Stats{{Collection: "persons", Updated: 1, Unchanged: 149}}, stats)
+
+	records, err := store.Records("persons")
+	assert.Nil(t, err)
+	var recovered struct {
+		Name  string `json:"Name"`
+		Tasks []int  `json:"Tasks"`
+	}
+	assert.Nil(t, json.Unmarshal(records[100], &recovered))
+	assert.Equal(t, "Updated", recovered.Name)
+	assert.Equal(t, []int{1}, recovered.Tasks)
+
+	checkpoint, err := store.Checkpoint(ctx, "persons")
+	assert.Nil(t, err)
+	assert.Len(t, checkpoint.Records, 150)
+}
+
+func TestSyncer_Sync_errors(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", personRecords(3)...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+	ctx := context.Background()
+
+	store, _ := NewFileStore(t.TempDir())
+	missing := testclient.New(srv).Collection("tasks")
+
+	stats, err := NewSyncer(store, persons, missing).Sync(ctx)
+	assert.True(t, errors.Is(err, adalo.ErrorResourceNotFound))
+	assert.Len(t, stats, 1)
+
+	// the failed collection is not checkpointed
+	checkpoint, err := store.Checkpoint(ctx, "tasks")
+	assert.Nil(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestSyncer_Run(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", personRecords(3)...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+
+	store, _ := NewFileStore(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var results [][]Stats
+	err := NewSyncer(store, persons).Run(ctx, time.Millisecond, func(stats []Stats, err error) {
+		assert.Nil(t, err)
+		results = append(results, stats)
+		if len(results) == 2 {
+			cancel()
+		}
+	})
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, results, 2)
+	assert.True(t, results[0][0].Full)
+	assert.Equal(t, 3, results[1][0].Unchanged)
+}
+
+func TestSyncer_Run_invalidInterval(t *testing.T) {
+	srv := adalotest.NewServer("key", "app").WithRecords("persons", personRecords(3)...)
+	defer srv.Close()
+	persons := testclient.New(srv).Collection("persons")
+
+	store, _ := NewFileStore(t.TempDir())
+	for _, interval := range []time.Duration{0, -time.Second} {
+		err := NewSyncer(store, persons).Run(context.Background(), interval, func(stats []Stats, err error) {
+			t.Error("unexpected sync")
+		})
+		assert.Error(t, err)
+	}
+	assert.Empty(t, srv.Requests())
+}
@@ -0,0 +1,206 @@
+package adalo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// TypedCollection provides a type safe CRUD interface to an Adalo collection.
+// Records of the collection are bound to T, while records are inserted and updated with I.
+//
+//	var Persons = adalo.NewTypedCollection[Person, PersonInput]("<ID-OF-PERSON-COLLECTION>")
+type TypedCollection[T any, I any] struct {
+	collection *Collection
+}
+
+// NewTypedCollection initializes a TypedCollection that uses the global ApiKey and AppID.
+func NewTypedCollection[T any, I any](collectionID string) *TypedCollection[T, I] {
+	return Typed[T, I](NewCollection(collectionID))
+}
+
+// Typed wraps the collection into a TypedCollection, e.g. to use a collection of a Client.
+func Typed[T any, I any](c *Collection) *TypedCollection[T, I] {
+	return &TypedCollection[T, I]{collection: c}
+}
+
+// Collection returns the underlying untyped Collection.
+func (tc *TypedCollection[T, I]) Collection() *Collection {
+	return tc.collection
+}
+
+// Where returns a copy of the collection whose All, List and Iterate only return records
+// whose field equals the value. See Collection.Where.
+func (tc *TypedCollection[T, I]) Where(field, value string) *TypedCollection[T, I] {
+	return Typed[T, I](tc.collection.Where(field, value))
+}
+
+// Include returns a copy of the collection that loads the records related through field
+// from the related collection. See Collection.Include.
+func (tc *TypedCollection[T, I]) Include(field string, related *Collection) *TypedCollection[T, I] {
+	return Typed[T, I](tc.collection.Include(field, related))
+}
+
+// WithoutIncludes returns a copy of the collection that does not load related records. See Collection.WithoutIncludes.
+func (tc *TypedCollection[T, I]) WithoutIncludes() *TypedCollection[T, I] {
+	return Typed[T, I](tc.collection.WithoutIncludes())
+}
+
+// Cached returns a copy of the collection whose responses are cached for ttl. See Collection.Cached.
+func (tc *TypedCollection[T, I]) Cached(ttl time.Duration) *TypedCollection[T, I] {
+	return Typed[T, I](tc.collection.Cached(ttl))
+}
+
+// All gets all records in the collection.
+func (tc *TypedCollection[T, I]) All(ctx context.Context) ([]T, error) {
+	var result []T
+	if err := tc.collection.AllContext(ctx, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// List fetches a single page of records from the collection.
+// The returned Page tells whether more records follow.
+func (tc *TypedCollection[T, I]) List(ctx context.Context, opts ListOptions) ([]T, *Page, error) {
+	page, err := tc.collection.List(ctx, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var result []T
+	if err := page.Bind(&result); err != nil {
+		return nil, nil, err
+	}
+	return result, page, nil
+}
+
+// Get fetches the record with the given id from the collection.
+func (tc *TypedCollection[T, I]) Get(ctx context.Context, id int) (T, error) {
+	var result T
+	err := tc.collection.GetContext(ctx, id, &result)
+	return result, err
+}
+
+// Insert inserts a new record to the collection and returns the created record.
+func (tc *TypedCollection[T, I]) Insert(ctx context.Context, input I) (T, error) {
+	var result T
+	err := tc.collection.InsertContext(ctx, input, &result)
+	return result, err
+}
+
+// Update updates the record with the given id and returns the updated record.
+func (tc *TypedCollection[T, I]) Update(ctx context.Context, id int, input I) (T, error) {
+	var result T
+	err := tc.collection.UpdateContext(ctx, id, input, &result)
+	return result, err
+}
+
+// UpdateIfUnchanged updates the record with the given id only if its updated_at still equals expectedUpdatedAt
+// and returns the updated record. See Collection.UpdateIfUnchanged.
+func (tc *TypedCollection[T, I]) UpdateIfUnchanged(ctx context.Context, id int, expectedUpdatedAt time.Time, input I) (T, error) {
+	var result T
+	err := tc.collection.UpdateIfUnchanged(ctx, id, expectedUpdatedAt, input, &result)
+	return result, err
+}
+
+// Modify updates the record with the given id with the input returned by mutate for its current state,
+// retrying on conflicts, and returns the updated record. See Collection.Modify.
+func (tc *TypedCollection[T, I]) Modify(ctx context.Context, id int, mutate func(current T) (I, error)) (T, error) {
+	var result T
+	err := tc.collection.modify(ctx, id, func(record json.RawMessage) (interface{}, error) {
+		var current T
+		if err := decodeResponse(record, &current); err != nil {
+			return nil, err
+		}
+		return mutate(current)
+	}, &result)
+	return result, err
+}
+
+// Delete removes the record with the given id from the collection.
+func (tc *TypedCollection[T, I]) Delete(ctx context.Context, id int) error {
+	return tc.collection.DeleteContext(ctx, id)
+}
+
+// UpdateRecord updates the passed record, e.g. a T embedding Record, and returns the updated record.
+func (tc *TypedCollection[T, I]) UpdateRecord(ctx context.Context, record Identifiable, input I) (T, error) {
+	return tc.Update(ctx, record.RecordID(), input)
+}
+
+// DeleteRecord removes the passed record, e.g. a T embedding Record, from the collection.
+func (tc *TypedCollection[T, I]) DeleteRecord(ctx context.Context, record Identifiable) error {
+	return tc.Delete(ctx, record.RecordID())
+}
+
+// Upsert updates the record whose keyField equals keyValue or inserts a new record if no record matches.
+// It returns the created or updated record and reports whether it was created. See Collection.Upsert.
+func (tc *TypedCollection[T, I]) Upsert(ctx context.Context, keyField, keyValue string, input I) (T, bool, error) {
+	var result T
+	created, err := tc.collection.Upsert(ctx, keyField, keyValue, input, &result)
+	return result, created, err
+}
+
+// InsertMany inserts a record for each input. See Collection.InsertMany.
+func (tc *TypedCollection[T, I]) InsertMany(ctx context.Context, inputs []I, opts BatchOptions) (*BatchResult, error) {
+	return tc.collection.InsertMany(ctx, inputs, opts)
+}
+
+// UpdateMany applies the updates to the records of the collection. See Collection.UpdateMany.
+func (tc *TypedCollection[T, I]) UpdateMany(ctx context.Context, updates []BatchUpdate, opts BatchOptions) (*BatchResult, error) {
+	return tc.collection.UpdateMany(ctx, updates, opts)
+}
+
+// DeleteMany removes the records with the given ids from the collection. See Collection.DeleteMany.
+func (tc *TypedCollection[T, I]) DeleteMany(ctx context.Context, ids []int, opts BatchOptions) (*BatchResult, error) {
+	return tc.collection.DeleteMany(ctx, ids, opts)
+}
+
+// Iterate returns a TypedIterator over the records of the collection. See Collection.Iterate.
+func (tc *TypedCollection[T, I]) Iterate(ctx context.Context, opts ListOptions) *TypedIterator[T] {
+	return &TypedIterator[T]{it: tc.collection.Iterate(ctx, opts)}
+}
+
+// TypedIterator walks through the records of a TypedCollection page by page.
+//
+//	it := persons.Iterate(ctx, adalo.ListOptions{})
+//	for it.Next() {
+//		person := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type TypedIterator[T any] struct {
+	it    *Iterator
+	value T
+	err   error
+}
+
+// Next advances the iterator to the next record, which is then available through Value.
+// It returns false when all records were consumed or an error occurred.
+func (it *TypedIterator[T]) Next() bool {
+	if it.err != nil || !it.it.Next() {
+		return false
+	}
+
+	var value T
+	if err := it.it.Scan(&value); err != nil {
+		it.err = err
+		return false
+	}
+	it.value = value
+	return true
+}
+
+// Value returns the current record.
+func (it *TypedIterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *TypedIterator[T]) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Err()
+}
@@ -0,0 +1,82 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTypedCollection(t *testing.T) {
+	srv := adalotest.NewServer("key", "app")
+	defer srv.Close()
+	srv.Seed("persons", personInput{Name: "John", Age: 21}, personInput{Name: "Jane", Age: 28})
+
+	persons := Typed[person, personInput](newTestClient(srv).Collection("persons"))
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		result, err := persons.All(ctx)
+		assert.Nil(t, err)
+		if assert.Len(t, result, 2) {
+			assert.Equal(t, 1, result[0].ID)
+			assert.Equal(t, "Jane", result[1].Name)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		result, page, err := persons.List(ctx, ListOptions{Limit: 2})
+		assert.Nil(t, err)
+		assert.Len(t, result, 2)
+		assert.True(t, page.HasMore())
+	})
+
+	t.Run("iterate", func(t *testing.T) {
+		it := persons.Iterate(ctx, ListOptions{})
+		var names []string
+		for it.Next() {
+			names = append(names, it.Value().Name)
+		}
+		assert.Nil(t, it.Err())
+		assert.Equal(t, []string{"John", "Jane"}, names)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		result, err := persons.Get(ctx, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, result.ID)
+		assert.Equal(t, "Jane", result.Name)
+
+		_, err = persons.Get(ctx, invalidID)
+		assert.True(t, errors.Is(err, ErrorResourceNotFound))
+	})
+
+	t.Run("insert", func(t *testing.T) {
+		result, err := persons.Insert(ctx, personInput{Name: "Richard", Age: 89})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, result.ID)
+		assert.Equal(t, "Richard", result.Name)
+		assert.Equal(t, 89, result.Age)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		result, err := persons.Update(ctx, 3, personInput{Name: "Rick", Age: 90})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, result.ID)
+		assert.Equal(t, "Rick", result.Name)
+		assert.Equal(t, 90, result.Age)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert.Nil(t, persons.Delete(ctx, 3))
+		_, ok := srv.Record("persons", 3)
+		assert.False(t, ok)
+	})
+
+	t.Run("where", func(t *testing.T) {
+		filtered := persons.Where("Name", "John")
+		assert.Equal(t, &Filter{Field: "Name", Value: "John"}, filtered.Collection().filter)
+		assert.Nil(t, persons.Collection().filter)
+	})
+}
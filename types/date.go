@@ -0,0 +1,121 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DateLayout is the format of the values of date fields.
+const DateLayout = "2006-01-02"
+
+// DateTimeLayout is the format of the values of date & time fields returned by Adalo.
+const DateTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Date is the value of a date field. The zero Date represents an empty field.
+type Date struct {
+	// Time is midnight in UTC of the day
+	Time time.Time
+}
+
+// NewDate returns the Date of the given day.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// IsZero reports whether d represents an empty field.
+func (d Date) IsZero() bool {
+	return d.Time.IsZero()
+}
+
+// String returns the date formatted as 2006-01-02, or an empty string for the zero Date.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Time.Format(DateLayout)
+}
+
+// MarshalJSON encodes the date as JSON string, or null for the zero Date.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return null, nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a date formatted as 2006-01-02. Dates with a time are truncated to the day.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	*d = Date{}
+	if isEmpty(data) {
+		return nil
+	}
+	s, err := unquote(data)
+	if err != nil {
+		return fmt.Errorf("invalid date %s: %w", data, err)
+	}
+
+	if t, err := time.Parse(DateLayout, s); err == nil {
+		*d = Date{t}
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return fmt.Errorf("invalid date %q", s)
+	}
+	*d = NewDate(t.Date())
+	return nil
+}
+
+// DateTime is the value of a date & time field. The zero DateTime represents an empty field.
+type DateTime struct {
+	// Time is the date & time, encoded in UTC
+	Time time.Time
+}
+
+// NewDateTime returns the DateTime of t.
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{t}
+}
+
+// IsZero reports whether dt represents an empty field.
+func (dt DateTime) IsZero() bool {
+	return dt.Time.IsZero()
+}
+
+// String returns the date & time in UTC formatted as DateTimeLayout, or an empty string for the zero DateTime.
+func (dt DateTime) String() string {
+	if dt.IsZero() {
+		return ""
+	}
+	return dt.Time.UTC().Format(DateTimeLayout)
+}
+
+// MarshalJSON encodes the date & time as JSON string, or null for the zero DateTime.
+func (dt DateTime) MarshalJSON() ([]byte, error) {
+	if dt.IsZero() {
+		return null, nil
+	}
+	return json.Marshal(dt.String())
+}
+
+// UnmarshalJSON decodes a date & time in RFC 3339 format. A date without time is decoded as midnight in UTC.
+func (dt *DateTime) UnmarshalJSON(data []byte) error {
+	*dt = DateTime{}
+	if isEmpty(data) {
+		return nil
+	}
+	s, err := unquote(data)
+	if err != nil {
+		return fmt.Errorf("invalid date & time %s: %w", data, err)
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		if t, err = time.Parse(DateLayout, s); err != nil {
+			return fmt.Errorf("invalid date & time %q", s)
+		}
+	}
+	*dt = DateTime{t}
+	return nil
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"encoding"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDate_UnmarshalJSON(t *testing.T) {
+	tests := map[string]Date{
+		`"2021-03-04"`:               NewDate(2021, time.March, 4),
+		`"2021-03-04T22:30:00.000Z"`: NewDate(2021, time.March, 4),
+		`null`:                       {},
+		`""`:                         {},
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			d := NewDate(2000, time.January, 1)
+			assert.Nil(t, json.Unmarshal([]byte(data), &d))
+			assert.Equal(t, want, d)
+		})
+	}
+
+	var d Date
+	assert.Error(t, json.Unmarshal([]byte(`"04.03.2021"`), &d))
+	assert.Error(t, json.Unmarshal([]byte(`20210304`), &d))
+}
+
+func TestDate_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Birthday Date `json:"Birthday"`
+		Empty    Date `json:"Empty"`
+		Omitted  Date `json:"Omitted,omitzero"`
+	}{Birthday: NewDate(2021, time.March, 4)})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"Birthday": "2021-03-04", "Empty": null}`, string(data))
+	assert.Equal(t, "2021-03-04", NewDate(2021, time.March, 4).String())
+	assert.Equal(t, "", Date{}.String())
+}
+
+func TestDate_TextMarshaler(t *testing.T) {
+	// the RFC 3339 text encoding of time.Time must not be promoted
+	for _, value := range []interface{}{Date{}, &Date{}, DateTime{}, &DateTime{}} {
+		_, ok := value.(encoding.TextMarshaler)
+		assert.False(t, ok, "%T", value)
+		_, ok = value.(encoding.TextUnmarshaler)
+		assert.False(t, ok, "%T", value)
+	}
+}
+
+func TestDateTime_UnmarshalJSON(t *testing.T) {
+	tests := map[string]DateTime{
+		`"2021-03-04T10:15:30.250Z"`:      NewDateTime(time.Date(2021, time.March, 4, 10, 15, 30, 250000000, time.UTC)),
+		`"2021-03-04T10:15:30Z"`:          NewDateTime(time.Date(2021, time.March, 4, 10, 15, 30, 0, time.UTC)),
+		`"2021-03-04"`:                    NewDateTime(time.Date(2021, time.March, 4, 0, 0, 0, 0, time.UTC)),
+		`"2021-03-04T12:15:30.250+02:00"`: NewDateTime(time.Date(2021, time.March, 4, 10, 15, 30, 250000000, time.UTC)),
+		`null`:                            {},
+		`""`:                              {},
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			var dt DateTime
+			assert.Nil(t, json.Unmarshal([]byte(data), &dt))
+			assert.True(t, want.Time.Equal(dt.Time), "want %s, got %s", want, dt)
+			assert.Equal(t, want.IsZero(), dt.IsZero())
+		})
+	}
+
+	var dt DateTime
+	assert.Error(t, json.Unmarshal([]byte(`"yesterday"`), &dt))
+}
+
+func TestDateTime_MarshalJSON(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	data, err := json.Marshal(struct {
+		Start DateTime `json:"Start"`
+		End   DateTime `json:"End"`
+	}{Start: NewDateTime(time.Date(2021, time.March, 4, 11, 15, 30, 0, berlin))})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"Start": "2021-03-04T10:15:30.000Z", "End": null}`, string(data))
+}
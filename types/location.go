@@ -0,0 +1,52 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+// Location is the value of a location field. The zero Location represents an empty field.
+type Location struct {
+	// Name of the place, e.g. Empire State Building
+	Name string `json:"name,omitempty"`
+
+	// FullAddress is the formatted address of the place
+	FullAddress string `json:"fullAddress,omitempty"`
+
+	// Coordinates of the place
+	Coordinates Coordinates `json:"coordinates"`
+}
+
+// Coordinates are the geographic coordinates of a Location.
+type Coordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// IsZero reports whether the location is empty.
+func (l Location) IsZero() bool {
+	return l == Location{}
+}
+
+// MarshalJSON encodes the location as JSON object, or null for the zero Location.
+func (l Location) MarshalJSON() ([]byte, error) {
+	if l.IsZero() {
+		return null, nil
+	}
+	type location Location
+	return json.Marshal(location(l))
+}
+
+// UnmarshalJSON decodes the location object returned by Adalo. A JSON string is decoded as the full address.
+func (l *Location) UnmarshalJSON(data []byte) error {
+	*l = Location{}
+	if isEmpty(data) {
+		return nil
+	}
+	if isString(data) {
+		s, err := unquote(data)
+		l.FullAddress = s
+		return err
+	}
+	type location Location
+	return json.Unmarshal(data, (*location)(l))
+}
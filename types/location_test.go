@@ -0,0 +1,31 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocation_UnmarshalJSON(t *testing.T) {
+	tests := map[string]Location{
+		`{"name": "Office", "fullAddress": "1 Main St, Springfield", "coordinates": {"latitude": 40.7, "longitude": -74.1}}`: {
+			Name: "Office", FullAddress: "1 Main St, Springfield", Coordinates: Coordinates{Latitude: 40.7, Longitude: -74.1},
+		},
+		`"1 Main St, Springfield"`: {FullAddress: "1 Main St, Springfield"},
+		`null`:                     {},
+		`""`:                       {},
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			location := Location{Name: "Old"}
+			assert.Nil(t, json.Unmarshal([]byte(data), &location))
+			assert.Equal(t, want, location)
+		})
+	}
+}
+
+func TestLocation_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal([]Location{{FullAddress: "1 Main St", Coordinates: Coordinates{Latitude: 1.5, Longitude: 2}}, {}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"fullAddress": "1 Main St", "coordinates": {"latitude": 1.5, "longitude": 2}}, null]`, string(data))
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"encoding/json"
+)
+
+// Image is the value of an image field. The zero Image represents an empty field.
+type Image struct {
+	// URL the image can be downloaded from
+	URL string `json:"url"`
+
+	// Filename is the original name of the uploaded image
+	Filename string `json:"filename,omitempty"`
+
+	// Size of the image in bytes
+	Size int `json:"size,omitempty"`
+
+	// Width and Height of the image in pixels
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// IsZero reports whether the image is empty.
+func (i Image) IsZero() bool {
+	return i == Image{}
+}
+
+// MarshalJSON encodes the image as JSON object, or null for the zero Image.
+func (i Image) MarshalJSON() ([]byte, error) {
+	if i.IsZero() {
+		return null, nil
+	}
+	type image Image
+	return json.Marshal(image(i))
+}
+
+// UnmarshalJSON decodes the image object returned by Adalo. A JSON string is decoded as the URL of the image.
+func (i *Image) UnmarshalJSON(data []byte) error {
+	*i = Image{}
+	type image Image
+	return unmarshalMedia(data, (*image)(i), &i.URL)
+}
+
+// File is the value of a file field. The zero File represents an empty field.
+type File struct {
+	// URL the file can be downloaded from
+	URL string `json:"url"`
+
+	// Filename is the original name of the uploaded file
+	Filename string `json:"filename,omitempty"`
+
+	// Size of the file in bytes
+	Size int `json:"size,omitempty"`
+}
+
+// IsZero reports whether the file is empty.
+func (f File) IsZero() bool {
+	return f == File{}
+}
+
+// MarshalJSON encodes the file as JSON object, or null for the zero File.
+func (f File) MarshalJSON() ([]byte, error) {
+	if f.IsZero() {
+		return null, nil
+	}
+	type file File
+	return json.Marshal(file(f))
+}
+
+// UnmarshalJSON decodes the file object returned by Adalo. A JSON string is decoded as the URL of the file.
+func (f *File) UnmarshalJSON(data []byte) error {
+	*f = File{}
+	type file File
+	return unmarshalMedia(data, (*file)(f), &f.URL)
+}
+
+// unmarshalMedia decodes the JSON object data into v, or into url if data is a JSON string.
+func unmarshalMedia(data []byte, v interface{}, url *string) error {
+	if isEmpty(data) {
+		return nil
+	}
+	if isString(data) {
+		s, err := unquote(data)
+		*url = s
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestImage_UnmarshalJSON(t *testing.T) {
+	tests := map[string]Image{
+		`{"url": "https://example.com/a.png", "filename": "a.png", "size": 1024, "width": 64, "height": 32}`: {
+			URL: "https://example.com/a.png", Filename: "a.png", Size: 1024, Width: 64, Height: 32,
+		},
+		`"https://example.com/a.png"`: {URL: "https://example.com/a.png"},
+		`null`:                        {},
+		`""`:                          {},
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			image := Image{URL: "https://example.com/old.png", Width: 10}
+			assert.Nil(t, json.Unmarshal([]byte(data), &image))
+			assert.Equal(t, want, image)
+		})
+	}
+
+	var image Image
+	assert.Error(t, json.Unmarshal([]byte(`42`), &image))
+}
+
+func TestImage_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal([]Image{{URL: "https://example.com/a.png", Width: 64, Height: 32}, {}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[{"url": "https://example.com/a.png", "width": 64, "height": 32}, null]`, string(data))
+}
+
+func TestFile_UnmarshalJSON(t *testing.T) {
+	var file File
+	assert.Nil(t, json.Unmarshal([]byte(`{"url": "https://example.com/a.pdf", "filename": "a.pdf", "size": 2048}`), &file))
+	assert.Equal(t, File{URL: "https://example.com/a.pdf", Filename: "a.pdf", Size: 2048}, file)
+
+	assert.Nil(t, json.Unmarshal([]byte(`null`), &file))
+	assert.True(t, file.IsZero())
+
+	assert.Nil(t, json.Unmarshal([]byte(`"https://example.com/b.pdf"`), &file))
+	assert.Equal(t, File{URL: "https://example.com/b.pdf"}, file)
+}
+
+func TestFile_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Contract File `json:"Contract"`
+		Invoice  File `json:"Invoice"`
+	}{Contract: File{URL: "https://example.com/a.pdf", Filename: "a.pdf"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"Contract": {"url": "https://example.com/a.pdf", "filename": "a.pdf"}, "Invoice": null}`, string(data))
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Relation is the value of a relationship field, which Adalo returns as the IDs of the related records.
+// If the related records were loaded with adalo.Collection.Include, they can be bound with Bind.
+type Relation struct {
+	// IDs of the related records
+	IDs []int
+
+	// Records contains the raw related records if they were loaded with adalo.Collection.Include
+	Records []json.RawMessage
+}
+
+// Loaded reports whether the related records were loaded.
+func (r Relation) Loaded() bool {
+	return r.Records != nil
+}
+
+// Bind binds the loaded related records to the passed result variable, which should point to a slice.
+func (r Relation) Bind(result interface{}) error {
+	records := r.Records
+	if records == nil {
+		records = []json.RawMessage{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, result)
+}
+
+// MarshalJSON encodes the relation as array of record IDs, as expected by the Adalo API.
+func (r Relation) MarshalJSON() ([]byte, error) {
+	if r.IDs == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(r.IDs)
+}
+
+// UnmarshalJSON decodes a record ID, an array of record IDs or the related records
+// loaded by adalo.Collection.Include. null is decoded as empty relation.
+func (r *Relation) UnmarshalJSON(data []byte) error {
+	*r = Relation{}
+	data = bytes.TrimSpace(data)
+
+	switch {
+	case bytes.Equal(data, null):
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := r.add(item); err != nil {
+				return err
+			}
+		}
+		if r.IDs == nil {
+			r.IDs = []int{}
+		}
+		return nil
+	default:
+		return r.add(data)
+	}
+}
+
+// add adds a record ID or a related record to the relation.
+func (r *Relation) add(item json.RawMessage) error {
+	item = bytes.TrimSpace(item)
+	if len(item) > 0 && item[0] == '{' {
+		id, err := RecordID(item)
+		if err != nil {
+			return err
+		}
+		r.IDs = append(r.IDs, id)
+		r.Records = append(r.Records, item)
+		return nil
+	}
+
+	var id int
+	if err := json.Unmarshal(item, &id); err != nil {
+		return fmt.Errorf("invalid relation value %s: %w", item, err)
+	}
+	r.IDs = append(r.IDs, id)
+	return nil
+}
+
+// RecordID returns the id of a raw record, like the related records of a Relation.
+func RecordID(record json.RawMessage) (int, error) {
+	var r struct {
+		ID int `json:"id"`
+	}
+	if err := json.Unmarshal(record, &r); err != nil {
+		return 0, err
+	}
+	return r.ID, nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRelation_UnmarshalJSON(t *testing.T) {
+	tests := map[string]Relation{
+		`[1, 2, 3]`:                    {IDs: []int{1, 2, 3}},
+		`[]`:                           {IDs: []int{}},
+		`null`:                         {},
+		`7`:                            {IDs: []int{7}},
+		`[{"id": 1}, {"id": 2}]`:       {IDs: []int{1, 2}, Records: []json.RawMessage{json.RawMessage(`{"id": 1}`), json.RawMessage(`{"id": 2}`)}},
+		`{"id": 4, "Title": "Task 4"}`: {IDs: []int{4}, Records: []json.RawMessage{json.RawMessage(`{"id": 4, "Title": "Task 4"}`)}},
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			var relation Relation
+			assert.Nil(t, json.Unmarshal([]byte(data), &relation))
+			assert.Equal(t, want, relation)
+		})
+	}
+
+	var relation Relation
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), &relation))
+}
+
+func TestRelation_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(struct {
+		Tasks Relation `json:"Tasks"`
+		Tags  Relation `json:"Tags"`
+	}{Tasks: Relation{IDs: []int{1, 2}}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"Tasks": [1, 2], "Tags": []}`, string(data))
+}
+
+func TestRelation_Bind(t *testing.T) {
+	var relation Relation
+	assert.Nil(t, json.Unmarshal([]byte(`[{"id": 1, "Title": "Task 1"}]`), &relation))
+	assert.True(t, relation.Loaded())
+
+	var tasks []struct {
+		ID    int    `json:"id"`
+		Title string `json:"Title"`
+	}
+	assert.Nil(t, relation.Bind(&tasks))
+	assert.Len(t, tasks, 1)
+	assert.Equal(t, "Task 1", tasks[0].Title)
+
+	assert.False(t, Relation{IDs: []int{1}}.Loaded())
+}
+
+func TestRecordID(t *testing.T) {
+	id, err := RecordID(json.RawMessage(`{"id": 7, "Title": "Task 7"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 7, id)
+
+	_, err = RecordID(json.RawMessage(`[7]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// Number is the value of a number field. Besides JSON numbers, it accepts numbers formatted
+// as JSON strings, as written by some Adalo integrations. null and empty strings are decoded as zero.
+type Number float64
+
+// Float64 returns the number as float64.
+func (n Number) Float64() float64 {
+	return float64(n)
+}
+
+// Int returns the number truncated to an int.
+func (n Number) Int() int {
+	return int(n)
+}
+
+// UnmarshalJSON decodes a JSON number or a number formatted as JSON string.
+func (n *Number) UnmarshalJSON(data []byte) error {
+	*n = 0
+	if isEmpty(data) {
+		return nil
+	}
+
+	s := string(bytes.TrimSpace(data))
+	if isString(data) {
+		var err error
+		if s, err = unquote(data); err != nil {
+			return err
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s", data)
+	}
+	*n = Number(f)
+	return nil
+}
+
+// Bool is the value of a true/false field. Besides JSON booleans, it accepts the numbers 0 and 1
+// and booleans formatted as JSON strings, e.g. "true" or "1". null and empty strings are decoded as false.
+type Bool bool
+
+// UnmarshalJSON decodes a JSON boolean, the number 0 or 1 or a boolean formatted as JSON string.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	*b = false
+	if isEmpty(data) {
+		return nil
+	}
+
+	s := string(bytes.TrimSpace(data))
+	if isString(data) {
+		var err error
+		if s, err = unquote(data); err != nil {
+			return err
+		}
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	*b = Bool(v)
+	return nil
+}
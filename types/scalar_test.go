@@ -0,0 +1,66 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNumber_UnmarshalJSON(t *testing.T) {
+	tests := map[string]Number{
+		`42`:     42,
+		`-1.5`:   -1.5,
+		`"42.5"`: 42.5,
+		`null`:   0,
+		`""`:     0,
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			n := Number(7)
+			assert.Nil(t, json.Unmarshal([]byte(data), &n))
+			assert.Equal(t, want, n)
+		})
+	}
+
+	var n Number
+	assert.Error(t, json.Unmarshal([]byte(`"many"`), &n))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &n))
+}
+
+func TestNumber_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Number(42.5))
+	assert.Nil(t, err)
+	assert.Equal(t, `42.5`, string(data))
+	assert.Equal(t, 42, Number(42.9).Int())
+	assert.Equal(t, 42.9, Number(42.9).Float64())
+}
+
+func TestBool_UnmarshalJSON(t *testing.T) {
+	tests := map[string]Bool{
+		`true`:    true,
+		`false`:   false,
+		`1`:       true,
+		`0`:       false,
+		`"true"`:  true,
+		`"false"`: false,
+		`null`:    false,
+		`""`:      false,
+	}
+	for data, want := range tests {
+		t.Run(data, func(t *testing.T) {
+			b := !want
+			assert.Nil(t, json.Unmarshal([]byte(data), &b))
+			assert.Equal(t, want, b)
+		})
+	}
+
+	var b Bool
+	assert.Error(t, json.Unmarshal([]byte(`"yes"`), &b))
+	assert.Error(t, json.Unmarshal([]byte(`2`), &b))
+}
+
+func TestBool_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(Bool(true))
+	assert.Nil(t, err)
+	assert.Equal(t, `true`, string(data))
+}
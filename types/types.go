@@ -0,0 +1,34 @@
+// Package types provides Go types for the values of Adalo collection fields.
+//
+// Each type marshals to and unmarshals from the JSON representation used by the Adalo API.
+// Empty fields, which Adalo returns as null or empty string, are decoded to the zero value,
+// and the zero value of Date, DateTime, Image, File and Location is encoded as null.
+// The zero values report true from IsZero, so optional fields can be omitted with the omitzero json option.
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// null is the JSON null literal.
+var null = []byte("null")
+
+// isEmpty reports whether data is JSON null or an empty JSON string.
+func isEmpty(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) == 0 || bytes.Equal(data, null) || bytes.Equal(data, []byte(`""`))
+}
+
+// isString reports whether data is a JSON string.
+func isString(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '"'
+}
+
+// unquote decodes the JSON string data.
+func unquote(data []byte) (string, error) {
+	var s string
+	err := json.Unmarshal(data, &s)
+	return s, err
+}
@@ -0,0 +1,38 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/be-foo/adalo-sdk-go/types"
+)
+
+// ErrorDuplicateKey is returned by Upsert when more than one record matches the key.
+var ErrorDuplicateKey = errors.New("more than one record matches the key")
+
+// Upsert updates the record whose keyField equals keyValue with the input, or inserts a new record
+// if no record matches. The created or updated record is bound to the passed result variable.
+// It reports whether the record was created and returns an error wrapping ErrorDuplicateKey
+// if more than one record matches.
+//
+// The lookup bypasses the cache of a Cached collection. The lookup and the write are separate requests,
+// so concurrent upserts of the same key may create duplicates.
+func (c *Collection) Upsert(ctx context.Context, keyField, keyValue string, input interface{}, result interface{}) (bool, error) {
+	page, err := c.uncached().Where(keyField, keyValue).List(ctx, ListOptions{Limit: 2})
+	if err != nil {
+		return false, err
+	}
+
+	switch len(page.Records) {
+	case 0:
+		return true, c.InsertContext(ctx, input, result)
+	case 1:
+		id, err := types.RecordID(page.Records[0])
+		if err != nil {
+			return false, err
+		}
+		return false, c.UpdateContext(ctx, id, input, result)
+	default:
+		return false, fmt.Errorf("%w: %s = %q", ErrorDuplicateKey, keyField, keyValue)
+	}
+}
@@ -0,0 +1,88 @@
+package adalo
+
+import (
+	"context"
+	"errors"
+	"github.com/be-foo/adalo-sdk-go/adalotest"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCollection_Upsert(t *testing.T) {
+	records := []interface{}{
+		personInput{Name: "John", Age: 21},
+		personInput{Name: "Jane", Age: 28},
+		personInput{Name: "Jane", Age: 35},
+	}
+
+	t.Run("updates existing record", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", records...)
+		defer srv.Close()
+		collection := newTestClient(srv).Collection("persons")
+
+		var result person
+		created, err := collection.Upsert(context.Background(), "Name", "John", personInput{Name: "John", Age: 22}, &result)
+
+		assert.Nil(t, err)
+		assert.False(t, created)
+		assert.Equal(t, 1, result.ID)
+		assert.Equal(t, 22, result.Age)
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, ""))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodPut, ""))
+		assert.Len(t, srv.Requests(), 2)
+	})
+
+	t.Run("inserts missing record", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", records...)
+		defer srv.Close()
+		collection := newTestClient(srv).Collection("persons")
+
+		persons := Typed[person, personInput](collection)
+		result, created, err := persons.Upsert(context.Background(), "Name", "Richard", personInput{Name: "Richard", Age: 89})
+
+		assert.Nil(t, err)
+		assert.True(t, created)
+		assert.Equal(t, 4, result.ID)
+		assert.Equal(t, "Richard", result.Name)
+		assert.Len(t, srv.Records("persons"), 4)
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, ""))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodPost, ""))
+		assert.Len(t, srv.Requests(), 2)
+	})
+
+	t.Run("with duplicate key", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", records...)
+		defer srv.Close()
+		collection := newTestClient(srv).Collection("persons")
+
+		_, err := collection.Upsert(context.Background(), "Name", "Jane", personInput{Name: "Jane"}, nil)
+
+		assert.True(t, errors.Is(err, ErrorDuplicateKey))
+		assert.Equal(t, 1, srv.CountRequests(http.MethodGet, ""))
+		assert.Len(t, srv.Requests(), 1)
+	})
+
+	t.Run("looks up the key bypassing the cache", func(t *testing.T) {
+		srv := adalotest.NewServer("key", "app").WithRecords("persons", records...)
+		defer srv.Close()
+		collection := newTestClient(srv).Collection("persons")
+		cached := collection.Cached(time.Minute)
+
+		// caches the empty result of the lookup
+		page, err := cached.Where("Name", "Richard").List(context.Background(), ListOptions{Limit: 2})
+		assert.Nil(t, err)
+		assert.Empty(t, page.Records)
+		srv.Seed("persons", personInput{Name: "Richard", Age: 88})
+
+		var result person
+		created, err := cached.Upsert(context.Background(), "Name", "Richard", personInput{Name: "Richard", Age: 89}, &result)
+
+		assert.Nil(t, err)
+		assert.False(t, created)
+		assert.Equal(t, 4, result.ID)
+		assert.Equal(t, 89, result.Age)
+		assert.Len(t, srv.Records("persons"), 4)
+	})
+}